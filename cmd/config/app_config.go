@@ -1,19 +1,34 @@
 package config
 
 import (
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/internal/api/handlers"
 	"Go-Starter-Template/internal/api/routes"
 	"Go-Starter-Template/internal/middleware"
 	"Go-Starter-Template/internal/utils"
 	"Go-Starter-Template/internal/utils/storage"
+	"Go-Starter-Template/pkg/alert"
+	"Go-Starter-Template/pkg/analytics"
+	"Go-Starter-Template/pkg/block"
 	"Go-Starter-Template/pkg/chat"
+	"Go-Starter-Template/pkg/comment"
 	"Go-Starter-Template/pkg/company"
+	"Go-Starter-Template/pkg/connection"
+	"Go-Starter-Template/pkg/feed"
 	"Go-Starter-Template/pkg/job"
 	"Go-Starter-Template/pkg/jwt"
 	"Go-Starter-Template/pkg/midtrans"
+	"Go-Starter-Template/pkg/moderation"
 	"Go-Starter-Template/pkg/notification"
+	"Go-Starter-Template/pkg/poll"
 	"Go-Starter-Template/pkg/post"
+	"Go-Starter-Template/pkg/reaction"
+	"Go-Starter-Template/pkg/recommendation"
+	"Go-Starter-Template/pkg/share"
+	"Go-Starter-Template/pkg/tag"
+	"Go-Starter-Template/pkg/trending"
 	"Go-Starter-Template/pkg/user"
+	"context"
 	"os"
 	"path/filepath"
 
@@ -28,7 +43,6 @@ func NewApp(db *gorm.DB) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
 		EnablePrintRoutes: true,
 	})
-	middlewares := middleware.NewMiddleware()
 	jwtService := jwt.NewJWTService()
 	validator := utils.Validate
 
@@ -65,18 +79,57 @@ func NewApp(db *gorm.DB) (*fiber.App, error) {
 	chatRepository := chat.NewChatRepository(db)
 	notificationRepository := notification.NewNotificationRepository(db)
 	postRepository := post.NewPostRepository(db)
+	connectionRepository := connection.NewConnectionRepository(db)
+	recommendationRepository := recommendation.NewRecommendationRepository(db)
+	blockRepository := block.NewBlockRepository(db)
+	feedRepository := feed.NewFeedRepository(db)
+	reactionRepository := reaction.NewReactionRepository(db)
+	commentRepository := comment.NewCommentRepository(db)
+	shareRepository := share.NewShareRepository(db)
+	tagRepository := tag.NewTagRepository(db)
+	moderationRepository := moderation.NewModerationRepository(db)
+	trendingRepository := trending.NewTrendingRepository(db)
+	pollRepository := poll.NewPollRepository(db)
+	analyticsRepository := analytics.NewAnalyticsRepository(db)
+	impressionBuffer := analytics.NewImpressionBuffer()
+	alertRepository := alert.NewAlertRepository(db)
+
+	middlewares := middleware.NewMiddleware(userRepository)
 
 	// Service
 	userService := user.NewUserService(userRepository, awsS3, jwtService)
-	companyService := company.NewCompanyService(companyRepository, awsS3, jwtService)
+	companyService := company.NewCompanyService(companyRepository, notificationRepository, reactionRepository, commentRepository, shareRepository, pollRepository, awsS3, jwtService)
 	midtransService := midtrans.NewMidtransService(
 		midtransRepository,
 		userRepository,
 	)
 	jobService := job.NewJobService(jobRepository, notificationRepository, awsS3, jwtService)
-	chatService := chat.NewChatService(chatRepository, notificationRepository, jwtService)
+	chatService := chat.NewChatService(chatRepository, notificationRepository, blockRepository, jwtService)
 	notificationService := notification.NewNotificationService(notificationRepository, jwtService)
-	postService := post.NewPostService(postRepository, awsS3, jwtService)
+	postService := post.NewPostService(postRepository, companyRepository, notificationRepository, tagRepository, awsS3, jwtService)
+	connectionService := connection.NewConnectionService(connectionRepository, userRepository, notificationRepository, blockRepository, jwtService)
+	recommendationService := recommendation.NewRecommendationService(recommendationRepository, jwtService)
+	blockService := block.NewBlockService(blockRepository, jwtService)
+	feedService := feed.NewFeedService(feedRepository, reactionRepository, commentRepository, shareRepository, pollRepository, impressionBuffer, jwtService)
+	reactionService := reaction.NewReactionService(reactionRepository, userRepository, notificationRepository, jwtService)
+	commentService := comment.NewCommentService(commentRepository, notificationRepository, jwtService)
+	shareService := share.NewShareService(shareRepository, notificationRepository, jwtService)
+	tagService := tag.NewTagService(tagRepository, notificationRepository, jwtService)
+	moderationService := moderation.NewModerationService(moderationRepository, notificationRepository, jwtService)
+	trendingService := trending.NewTrendingService(trendingRepository, jwtService)
+	pollService := poll.NewPollService(pollRepository, notificationRepository, jwtService)
+	analyticsService := analytics.NewAnalyticsService(analyticsRepository, impressionBuffer, jwtService)
+	alertService := alert.NewAlertService(alertRepository, notificationRepository, jwtService)
+
+	// background jobs
+	go utils.RunEvery(context.Background(), domain.PostPublishInterval, "publish scheduled posts", postService.PublishDuePosts)
+	go utils.RunEvery(context.Background(), domain.PostRevisionPurgeInterval, "purge post revision files", postService.PurgeRevisionFiles)
+	go utils.RunEvery(context.Background(), domain.TrendingInterval, "compute trending topics", trendingService.ComputeTrending)
+	go utils.RunEvery(context.Background(), domain.PollCloseInterval, "notify closed polls", pollService.NotifyClosedPolls)
+	go utils.RunEvery(context.Background(), domain.ImpressionFlushInterval, "flush post impressions", analyticsService.FlushImpressions)
+	go utils.RunEvery(context.Background(), domain.JobExpireInterval, "expire jobs", companyService.ExpireJobs)
+	go utils.RunEvery(context.Background(), domain.JobDeadlineReminderInterval, "remind saved job deadlines", jobService.SendDeadlineReminders)
+	go utils.RunEvery(context.Background(), domain.JobAlertInterval, "deliver job alerts", alertService.DeliverAlerts)
 
 	// Handler
 	userHandler := handlers.NewUserHandler(userService, validator)
@@ -87,20 +140,46 @@ func NewApp(db *gorm.DB) (*fiber.App, error) {
 	chatHandler := handlers.NewChatHandler(chatService, validator)
 	notificationHandler := handlers.NewNotificationHandler(notificationService, validator)
 	postHandler := handlers.NewPostHandler(postService, validator)
+	connectionHandler := handlers.NewConnectionHandler(connectionService, validator)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService, validator)
+	blockHandler := handlers.NewBlockHandler(blockService, validator)
+	feedHandler := handlers.NewFeedHandler(feedService, validator)
+	reactionHandler := handlers.NewReactionHandler(reactionService, validator)
+	commentHandler := handlers.NewCommentHandler(commentService, validator)
+	shareHandler := handlers.NewShareHandler(shareService, validator)
+	tagHandler := handlers.NewTagHandler(tagService, validator)
+	moderationHandler := handlers.NewModerationHandler(moderationService, validator)
+	trendingHandler := handlers.NewTrendingHandler(trendingService, validator)
+	pollHandler := handlers.NewPollHandler(pollService, validator)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, validator)
+	alertHandler := handlers.NewAlertHandler(alertService, validator)
 
 	// routes
 	routesConfig := routes.Config{
-		App:                 app,
-		UserHandler:         userHandler,
-		CompanyHandler:      companyHandler,
-		MidtransHandler:     midtransHandler,
-		Middleware:          middlewares,
-		JwtService:          jwtService,
-		JobHandler:          jobHandler,
-		ChatServerHandler:   *chatServerHandler,
-		ChatHandler:         chatHandler,
-		NotificationHandler: notificationHandler,
-		PostHandler:         postHandler,
+		App:                   app,
+		UserHandler:           userHandler,
+		CompanyHandler:        companyHandler,
+		MidtransHandler:       midtransHandler,
+		Middleware:            middlewares,
+		JwtService:            jwtService,
+		JobHandler:            jobHandler,
+		ChatServerHandler:     chatServerHandler,
+		ChatHandler:           chatHandler,
+		NotificationHandler:   notificationHandler,
+		PostHandler:           postHandler,
+		ConnectionHandler:     connectionHandler,
+		RecommendationHandler: recommendationHandler,
+		BlockHandler:          blockHandler,
+		FeedHandler:           feedHandler,
+		ReactionHandler:       reactionHandler,
+		CommentHandler:        commentHandler,
+		ShareHandler:          shareHandler,
+		TagHandler:            tagHandler,
+		ModerationHandler:     moderationHandler,
+		TrendingHandler:       trendingHandler,
+		PollHandler:           pollHandler,
+		AnalyticsHandler:      analyticsHandler,
+		AlertHandler:          alertHandler,
 	}
 
 	routesConfig.Setup()
@@ -21,6 +21,7 @@ func DatabaseSetUp() (*gorm.DB, error) {
 	}
 
 	migrateFlag := flag.Bool("migrate", false, "migrating the database")
+	adminFlag := flag.String("admin", "", "email of the user account to give the admin role")
 
 	flag.Parse()
 
@@ -29,6 +30,12 @@ func DatabaseSetUp() (*gorm.DB, error) {
 			return nil, err
 		}
 	}
+
+	if *adminFlag != "" {
+		if err := migration.PromoteAdmin(db, *adminFlag); err != nil {
+			return nil, err
+		}
+	}
 	return db, nil
 }
 
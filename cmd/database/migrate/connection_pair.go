@@ -0,0 +1,14 @@
+package migration
+
+// connectionPairSQL allows a single row per pair of users, whichever way the
+// request went. Pairs that already have a row in each direction keep the
+// accepted one, or else the most recently updated one.
+const connectionPairSQL = `
+DELETE FROM user_connections a
+USING user_connections b
+WHERE a.user_id = b.connected_with_id AND a.connected_with_id = b.user_id
+	AND (b.status = 'accepted', b.updated_at, b.user_id) > (a.status = 'accepted', a.updated_at, a.user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_connections_pair
+	ON user_connections (LEAST(user_id, connected_with_id), GREATEST(user_id, connected_with_id));
+`
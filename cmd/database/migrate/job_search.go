@@ -0,0 +1,86 @@
+package migration
+
+import (
+	"gorm.io/gorm"
+)
+
+// jobSearchSQL keeps jobs.search_vector up to date with the job's title,
+// company name, skill names and description, weighted in that order.
+const jobSearchSQL = `
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION job_search_document(p_job_id uuid, p_title text, p_description text, p_company_id uuid)
+RETURNS tsvector AS $$
+	SELECT setweight(to_tsvector('english', coalesce(p_title, '')), 'A')
+		|| setweight(to_tsvector('english', coalesce((
+			SELECT c.name FROM companies c WHERE c.id = p_company_id
+		), '')), 'B')
+		|| setweight(to_tsvector('english', coalesce((
+			SELECT string_agg(s.name, ' ')
+			FROM job_skills js
+			JOIN skills s ON s.id = js.skill_id
+			WHERE js.job_id = p_job_id AND js.deleted_at IS NULL
+		), '')), 'B')
+		|| setweight(to_tsvector('english', coalesce(p_description, '')), 'C')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION jobs_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector := job_search_document(NEW.id, NEW.title, NEW.description, NEW.company_id);
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS jobs_search_vector_update ON jobs;
+CREATE TRIGGER jobs_search_vector_update
+	BEFORE INSERT OR UPDATE OF title, description, company_id ON jobs
+	FOR EACH ROW EXECUTE FUNCTION jobs_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION job_skills_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	UPDATE jobs SET search_vector = job_search_document(id, title, description, company_id)
+	WHERE id = CASE WHEN TG_OP = 'DELETE' THEN OLD.job_id ELSE NEW.job_id END;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS job_skills_search_vector_update ON job_skills;
+CREATE TRIGGER job_skills_search_vector_update
+	AFTER INSERT OR UPDATE OR DELETE ON job_skills
+	FOR EACH ROW EXECUTE FUNCTION job_skills_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION companies_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	UPDATE jobs SET search_vector = job_search_document(id, title, description, company_id)
+	WHERE company_id = NEW.id;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS companies_search_vector_update ON companies;
+CREATE TRIGGER companies_search_vector_update
+	AFTER UPDATE OF name ON companies
+	FOR EACH ROW EXECUTE FUNCTION companies_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION skills_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	UPDATE jobs SET search_vector = job_search_document(id, title, description, company_id)
+	WHERE id IN (SELECT job_id FROM job_skills WHERE skill_id = NEW.id AND deleted_at IS NULL);
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS skills_search_vector_update ON skills;
+CREATE TRIGGER skills_search_vector_update
+	AFTER UPDATE OF name ON skills
+	FOR EACH ROW EXECUTE FUNCTION skills_search_vector_trigger();
+
+UPDATE jobs SET search_vector = job_search_document(id, title, description, company_id)
+WHERE search_vector IS NULL;
+`
+
+func migrateJobSearch(db *gorm.DB) error {
+	return db.Exec(jobSearchSQL).Error
+}
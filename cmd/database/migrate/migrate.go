@@ -1,9 +1,11 @@
 package migration
 
 import (
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
 	"fmt"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -30,6 +32,15 @@ func Migrate(db *gorm.DB) error {
 		log.Fatalf("Error migrating job database: %v", err)
 		return err
 	}
+	// jobs used to be created as "active", which is an open posting. They are
+	// counted as opened now rather than when they were created, so the ones
+	// without a deadline are not all expired as stale on the first run.
+	if err := db.Model(&entities.Job{}).
+		Where("status = ? OR status = ''", "active").
+		Updates(map[string]interface{}{"status": domain.JobStatusOpen, "opened_at": time.Now()}).Error; err != nil {
+		log.Fatalf("Error migrating job statuses: %v", err)
+		return err
+	}
 	if err := db.AutoMigrate(&entities.JobSkill{}); err != nil {
 		log.Fatalf("Error migrating job skill database: %v", err)
 		return err
@@ -46,6 +57,10 @@ func Migrate(db *gorm.DB) error {
 		log.Fatalf("Error migrating user connection database: %v", err)
 		return err
 	}
+	if err := db.Exec(connectionPairSQL).Error; err != nil {
+		log.Fatalf("Error migrating user connection pairs: %v", err)
+		return err
+	}
 	if err := db.AutoMigrate(&entities.UserEducation{}); err != nil {
 		log.Fatalf("Error migrating user education database: %v", err)
 		return err
@@ -58,6 +73,14 @@ func Migrate(db *gorm.DB) error {
 		log.Fatalf("Error migrating user skill database: %v", err)
 		return err
 	}
+	// views used to be keyed by (user_id, viewer_id) only and was never
+	// written to, so it is safe to recreate it with the per-day key.
+	if db.Migrator().HasTable(&entities.Views{}) && !db.Migrator().HasColumn(&entities.Views{}, "viewed_on") {
+		if err := db.Migrator().DropTable(&entities.Views{}); err != nil {
+			log.Fatalf("Error recreating views database: %v", err)
+			return err
+		}
+	}
 	if err := db.AutoMigrate(&entities.Views{}); err != nil {
 		log.Fatalf("Error migrating views database: %v", err)
 		return err
@@ -75,6 +98,88 @@ func Migrate(db *gorm.DB) error {
 		log.Fatalf("Error migrating notifications database: %v", err)
 	}
 
+	if err := db.AutoMigrate(&entities.CompanyFollower{}); err != nil {
+		log.Fatalf("Error migrating company followers database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.UserBlock{}); err != nil {
+		log.Fatalf("Error migrating user blocks database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostReaction{}); err != nil {
+		log.Fatalf("Error migrating post reactions database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostComment{}); err != nil {
+		log.Fatalf("Error migrating post comments database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.Hashtag{}, &entities.PostHashtag{}, &entities.HashtagFollower{}); err != nil {
+		log.Fatalf("Error migrating hashtags database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostMention{}); err != nil {
+		log.Fatalf("Error migrating post mentions database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostAttachment{}); err != nil {
+		log.Fatalf("Error migrating post attachments database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostRevision{}, &entities.PostRevisionAttachment{}); err != nil {
+		log.Fatalf("Error migrating post revisions database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.Report{}, &entities.ModerationAction{}); err != nil {
+		log.Fatalf("Error migrating moderation database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.TrendingTopic{}); err != nil {
+		log.Fatalf("Error migrating trending topics database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.Poll{}, &entities.PollOption{}, &entities.PollVote{}); err != nil {
+		log.Fatalf("Error migrating polls database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.PostImpression{}, &entities.PostProfileClick{}); err != nil {
+		log.Fatalf("Error migrating post analytics database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.JobAlert{}, &entities.JobAlertDelivery{}); err != nil {
+		log.Fatalf("Error migrating job alerts database: %v", err)
+	}
+
+	if err := db.AutoMigrate(&entities.SavedJob{}); err != nil {
+		log.Fatalf("Error migrating saved jobs database: %v", err)
+	}
+
+	if err := migrateJobSearch(db); err != nil {
+		log.Fatalf("Error migrating job search: %v", err)
+	}
+
+	if err := migratePostEngagement(db); err != nil {
+		log.Fatalf("Error migrating post engagement: %v", err)
+	}
+
 	fmt.Println("Database migration complete")
 	return nil
 }
+
+// PromoteAdmin gives the admin role to an existing user account.
+func PromoteAdmin(db *gorm.DB, email string) error {
+	res := db.Model(&entities.User{}).
+		Where("email = ? AND role = ?", email, domain.RoleUser).
+		Update("role", domain.RoleAdmin)
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("no user account with email %s", email)
+	}
+
+	fmt.Println("Promoted " + email + " to admin")
+	return nil
+}
@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"Go-Starter-Template/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// postEngagementSQL keeps count of the reactions, comments and shares of
+// every post, along with their weighted engagement and the feed score
+// ranking it. Comments and shares hidden by a moderator do not count.
+// post_engagement_counts is the one definition of what counts, also used to
+// count the interactions of a period. Posts without a feed_score are
+// backfilled, so clearing feed_score recomputes it after a weight change.
+var postEngagementSQL = fmt.Sprintf(`
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_count bigint NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comment_count bigint NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS share_count bigint NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS engagement bigint NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS feed_score double precision;
+
+CREATE INDEX IF NOT EXISTS idx_posts_feed_score ON posts (feed_score DESC, id DESC);
+
+CREATE OR REPLACE FUNCTION post_engagement_counts(p_post_id uuid, p_since timestamp)
+RETURNS TABLE (reactions bigint, comments bigint, shares bigint) AS $$
+	SELECT
+		(SELECT COUNT(*) FROM post_reactions pr
+			WHERE pr.post_id = p_post_id AND pr.created_at >= p_since AND pr.deleted_at IS NULL),
+		(SELECT COUNT(*) FROM post_comments pc
+			WHERE pc.post_id = p_post_id AND pc.created_at >= p_since AND pc.hidden_at IS NULL AND pc.deleted_at IS NULL),
+		(SELECT COUNT(*) FROM posts sp
+			WHERE sp.shared_post_id = p_post_id AND sp.created_at >= p_since AND sp.hidden_at IS NULL AND sp.deleted_at IS NULL)
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION refresh_post_engagement(p_post_id uuid) RETURNS void AS $$
+	UPDATE posts SET (reaction_count, comment_count, share_count) = (
+		SELECT reactions, comments, shares FROM post_engagement_counts(p_post_id, '-infinity')
+	)
+	WHERE id = p_post_id
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION posts_feed_score_trigger() RETURNS trigger AS $$
+BEGIN
+	NEW.engagement := NEW.reaction_count * %d + NEW.comment_count * %d + NEW.share_count * %d;
+	NEW.feed_score := LOG(1 + NEW.engagement::double precision) + EXTRACT(EPOCH FROM NEW.created_at)::double precision / %d;
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS posts_feed_score_update ON posts;
+CREATE TRIGGER posts_feed_score_update
+	BEFORE INSERT OR UPDATE OF reaction_count, comment_count, share_count, created_at ON posts
+	FOR EACH ROW EXECUTE FUNCTION posts_feed_score_trigger();
+
+CREATE OR REPLACE FUNCTION post_interactions_engagement_trigger() RETURNS trigger AS $$
+BEGIN
+	PERFORM refresh_post_engagement(CASE WHEN TG_OP = 'DELETE' THEN OLD.post_id ELSE NEW.post_id END);
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS post_reactions_engagement_update ON post_reactions;
+CREATE TRIGGER post_reactions_engagement_update
+	AFTER INSERT OR UPDATE OF deleted_at OR DELETE ON post_reactions
+	FOR EACH ROW EXECUTE FUNCTION post_interactions_engagement_trigger();
+
+DROP TRIGGER IF EXISTS post_comments_engagement_update ON post_comments;
+CREATE TRIGGER post_comments_engagement_update
+	AFTER INSERT OR UPDATE OF hidden_at, deleted_at OR DELETE ON post_comments
+	FOR EACH ROW EXECUTE FUNCTION post_interactions_engagement_trigger();
+
+CREATE OR REPLACE FUNCTION post_shares_engagement_trigger() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP <> 'INSERT' AND OLD.shared_post_id IS NOT NULL THEN
+		PERFORM refresh_post_engagement(OLD.shared_post_id);
+	END IF;
+	IF TG_OP <> 'DELETE' AND NEW.shared_post_id IS NOT NULL THEN
+		PERFORM refresh_post_engagement(NEW.shared_post_id);
+	END IF;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS post_shares_engagement_update ON posts;
+CREATE TRIGGER post_shares_engagement_update
+	AFTER INSERT OR UPDATE OF shared_post_id, hidden_at, deleted_at OR DELETE ON posts
+	FOR EACH ROW EXECUTE FUNCTION post_shares_engagement_trigger();
+
+UPDATE posts SET (reaction_count, comment_count, share_count) = (
+	SELECT reactions, comments, shares FROM post_engagement_counts(posts.id, '-infinity')
+)
+WHERE feed_score IS NULL;
+`, domain.FeedWeightReaction, domain.FeedWeightComment, domain.FeedWeightShare, domain.FeedGravity)
+
+func migratePostEngagement(db *gorm.DB) error {
+	return db.Exec(postEngagementSQL).Error
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	JobAlertFrequencyInstant = "instant"
+	JobAlertFrequencyDaily   = "daily"
+	JobAlertFrequencyWeekly  = "weekly"
+
+	// JobAlertInterval is how often alerts are checked, which is also how
+	// soon instant alerts go out.
+	JobAlertInterval = 5 * time.Minute
+	JobAlertBatch    = 100
+
+	// JobAlertMaxJobs caps how many jobs one alert sends at a time.
+	JobAlertMaxJobs    = 20
+	JobAlertMaxPerUser = 20
+
+	NotificationTypeJobAlert = "Job Alert"
+)
+
+// JobAlertPeriods is how long each frequency waits between two runs.
+var JobAlertPeriods = map[string]time.Duration{
+	JobAlertFrequencyInstant: JobAlertInterval,
+	JobAlertFrequencyDaily:   24 * time.Hour,
+	JobAlertFrequencyWeekly:  7 * 24 * time.Hour,
+}
+
+var (
+	MessageSuccessCreateJobAlert = "Successfully create job alert"
+	MessageSuccessGetJobAlerts   = "Successfully get job alerts"
+	MessageSuccessUpdateJobAlert = "Successfully update job alert"
+	MessageSuccessDeleteJobAlert = "Successfully delete job alert"
+
+	MessageFailedCreateJobAlert = "Failed to create job alert"
+	MessageFailedGetJobAlerts   = "Failed to get job alerts"
+	MessageFailedUpdateJobAlert = "Failed to update job alert"
+	MessageFailedDeleteJobAlert = "Failed to delete job alert"
+
+	ErrJobAlertNotFound     = errors.New("job alert not found")
+	ErrJobAlertLimitReached = errors.New("job alert limit reached")
+	ErrCreateJobAlert       = errors.New("failed to create job alert")
+	ErrGetJobAlerts         = errors.New("failed to get job alerts")
+	ErrUpdateJobAlert       = errors.New("failed to update job alert")
+	ErrDeleteJobAlert       = errors.New("failed to delete job alert")
+)
+
+type (
+	// JobAlertRequest saves the filters of a job search. Alerts only send
+	// new postings, so the date posted and sort order are not kept.
+	JobAlertRequest struct {
+		Name      string `json:"name" form:"name" validate:"required,max=100"`
+		Frequency string `json:"frequency" form:"frequency" validate:"required,oneof=instant daily weekly"`
+		JobSearchRequest
+	}
+
+	JobAlertResponse struct {
+		ID              string `json:"id"`
+		Name            string `json:"name"`
+		Frequency       string `json:"frequency"`
+		Query           string `json:"q"`
+		JobType         string `json:"job_type"`
+		LocationType    string `json:"location_type"`
+		ExperienceLevel string `json:"experience_level"`
+		MinSalary       int    `json:"min_salary"`
+		MaxSalary       int    `json:"max_salary"`
+		NextRunAt       string `json:"next_run_at"`
+		CreatedAt       string `json:"created_at"`
+	}
+)
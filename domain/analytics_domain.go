@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PostAnalyticsDefaultDays = 30
+	PostAnalyticsMaxDays     = 90
+	PostAnalyticsBreakdowns  = 10
+
+	// ImpressionFlushInterval is how often buffered feed impressions are
+	// written to the database.
+	ImpressionFlushInterval = 10 * time.Second
+	ImpressionBatchSize     = 500
+)
+
+var (
+	MessageSuccessGetPostAnalytics   = "Successfully get post analytics"
+	MessageSuccessRecordProfileClick = "Successfully record profile click"
+
+	MessageFailedGetPostAnalytics   = "Failed to get post analytics"
+	MessageFailedRecordProfileClick = "Failed to record profile click"
+
+	ErrGetPostAnalytics   = errors.New("failed to get post analytics")
+	ErrRecordProfileClick = errors.New("failed to record profile click")
+)
+
+type (
+	// PostAnalyticsResponse covers the last Days days. The viewer breakdowns
+	// are only filled in for premium authors.
+	PostAnalyticsResponse struct {
+		PostID          string                        `json:"post_id"`
+		Days            int                           `json:"days"`
+		Impressions     int64                         `json:"impressions"`
+		UniqueViewers   int64                         `json:"unique_viewers"`
+		ProfileClicks   int64                         `json:"profile_clicks"`
+		Reactions       int64                         `json:"reactions"`
+		Comments        int64                         `json:"comments"`
+		Shares          int64                         `json:"shares"`
+		Engagement      int64                         `json:"engagement"`
+		EngagementRate  float64                       `json:"engagement_rate"`
+		Daily           []PostImpressionCountResponse `json:"daily"`
+		BreakdownLocked bool                          `json:"breakdown_locked"`
+		ViewerTitles    []ViewerBreakdownResponse     `json:"viewer_titles"`
+		ViewerCompanies []ViewerBreakdownResponse     `json:"viewer_companies"`
+	}
+
+	PostImpressionCountResponse struct {
+		Date        string `json:"date"`
+		Impressions int64  `json:"impressions"`
+	}
+
+	ViewerBreakdownResponse struct {
+		Name    string `json:"name"`
+		Viewers int64  `json:"viewers"`
+	}
+)
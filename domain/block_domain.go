@@ -0,0 +1,45 @@
+package domain
+
+import "errors"
+
+const (
+	BlockTypeBlock = "block"
+	BlockTypeMute  = "mute"
+)
+
+var (
+	MessageSuccessBlockUser       = "Successfully block user"
+	MessageSuccessUnblockUser     = "Successfully unblock user"
+	MessageSuccessMuteUser        = "Successfully mute user"
+	MessageSuccessUnmuteUser      = "Successfully unmute user"
+	MessageSuccessGetBlockedUsers = "Successfully get blocked users"
+	MessageSuccessGetMutedUsers   = "Successfully get muted users"
+
+	MessageFailedBlockUser       = "Failed to block user"
+	MessageFailedUnblockUser     = "Failed to unblock user"
+	MessageFailedMuteUser        = "Failed to mute user"
+	MessageFailedUnmuteUser      = "Failed to unmute user"
+	MessageFailedGetBlockedUsers = "Failed to get blocked users"
+	MessageFailedGetMutedUsers   = "Failed to get muted users"
+
+	ErrBlockSelf       = errors.New("cannot block or mute yourself")
+	ErrUserBlocked     = errors.New("user is blocked")
+	ErrBlockNotFound   = errors.New("user is not blocked")
+	ErrMuteNotFound    = errors.New("user is not muted")
+	ErrBlockUser       = errors.New("failed to block user")
+	ErrUnblockUser     = errors.New("failed to unblock user")
+	ErrGetBlockedUsers = errors.New("failed to get blocked users")
+	ErrCheckBlock      = errors.New("failed to check block status")
+)
+
+type (
+	BlockedUserResponse struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Slug           string `json:"slug"`
+		ProfilePicture string `json:"profile_picture"`
+		Headline       string `json:"headline"`
+		Type           string `json:"type"`
+		Since          string `json:"since"`
+	}
+)
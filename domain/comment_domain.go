@@ -0,0 +1,55 @@
+package domain
+
+import "errors"
+
+const (
+	NotificationTypeComment = "Comment"
+)
+
+var (
+	MessageSuccessCreateComment = "Successfully create comment"
+	MessageSuccessUpdateComment = "Successfully update comment"
+	MessageSuccessDeleteComment = "Successfully delete comment"
+	MessageSuccessGetComments   = "Successfully get comments"
+
+	MessageFailedCreateComment = "Failed to create comment"
+	MessageFailedUpdateComment = "Failed to update comment"
+	MessageFailedDeleteComment = "Failed to delete comment"
+	MessageFailedGetComments   = "Failed to get comments"
+
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrCommentReplyDepth   = errors.New("replies can only be made to top-level comments")
+	ErrCommentPostMismatch = errors.New("parent comment belongs to another post")
+	ErrCreateComment       = errors.New("failed to create comment")
+	ErrUpdateComment       = errors.New("failed to update comment")
+	ErrDeleteComment       = errors.New("failed to delete comment")
+	ErrGetComments         = errors.New("failed to get comments")
+)
+
+type (
+	CreateCommentRequest struct {
+		PostID   string `json:"post_id" form:"post_id" validate:"required"`
+		ParentID string `json:"parent_id" form:"parent_id"`
+		Content  string `json:"content" form:"content" validate:"required"`
+	}
+
+	UpdateCommentRequest struct {
+		ID      string `json:"id" form:"id" validate:"required"`
+		Content string `json:"content" form:"content" validate:"required"`
+	}
+
+	CommentResponse struct {
+		ID             string `json:"id"`
+		PostID         string `json:"post_id"`
+		ParentID       string `json:"parent_id,omitempty"`
+		UserID         string `json:"user_id"`
+		Name           string `json:"name"`
+		Slug           string `json:"slug"`
+		ProfilePicture string `json:"profile_picture"`
+		Headline       string `json:"headline"`
+		Content        string `json:"content"`
+		ReplyCount     int64  `json:"reply_count"`
+		IsEdited       bool   `json:"is_edited"`
+		CreatedAt      string `json:"created_at"`
+	}
+)
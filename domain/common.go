@@ -6,8 +6,8 @@ import (
 )
 
 const (
-	RoleUser = "user"
-	//ROLE_ADMIN  = "admin"
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 	//ROLE_MENTOR = "mentor"
 )
 
@@ -10,11 +10,19 @@ var (
 	MessageSuccessUpdateProfileCompany = "Company profile updated successfully"
 	MessageSuccessRegisterCompany      = "Company registered successfully"
 	MessageSuccessGetListCompany       = "Company profile retrieved successfully"
+	MessageSuccessFollowCompany        = "Company followed successfully"
+	MessageSuccessUnfollowCompany      = "Company unfollowed successfully"
+	MessageSuccessGetFollowedCompanies = "Followed companies retrieved successfully"
+	MessageSuccessChangeJobStatus      = "Job status changed successfully"
 
 	MessageFailedAddJob               = "Failed to add job"
 	MessageFailedUpdateProfileCompany = "Failed to update company profile"
 	MessageFailedRegisterCompany      = "Failed to register company"
 	MessageFailedGetListCompany       = "Failed to retrieve company profile"
+	MessageFailedFollowCompany        = "Failed to follow company"
+	MessageFailedUnfollowCompany      = "Failed to unfollow company"
+	MessageFailedGetFollowedCompanies = "Failed to retrieve followed companies"
+	MessageFailedChangeJobStatus      = "Failed to change job status"
 
 	ErrJobNotCreated            = errors.New("job not created")
 	ErrJobNotUpdated            = errors.New("job not updated")
@@ -22,6 +30,18 @@ var (
 	ErrCompanyNotRegistered     = errors.New("company not created")
 	ErrCompanyAlreadyRegistered = errors.New("company already registered")
 	ErrCompanyNotFound          = errors.New("company not found")
+	ErrCompanyAlreadyFollowed   = errors.New("company already followed")
+	ErrCompanyNotFollowed       = errors.New("company not followed")
+	ErrFollowCompany            = errors.New("failed to follow company")
+	ErrUnfollowCompany          = errors.New("failed to unfollow company")
+	ErrGetFollowedCompanies     = errors.New("failed to get followed companies")
+	ErrInvalidJobStatus         = errors.New("job status must be draft or open")
+	ErrJobStatusTransition      = errors.New("job cannot move to this status")
+	ErrInvalidJobDeadline       = errors.New("deadline must be an RFC 3339 timestamp")
+	ErrJobDeadlineInPast        = errors.New("deadline must be in the future")
+	ErrChangeJobStatus          = errors.New("failed to change job status")
+
+	NotificationTypeCompany = "Company"
 )
 
 type (
@@ -41,15 +61,36 @@ type (
 		Asset          string `json:"asset"`
 		Slug           string `json:"slug"`
 		Type           string `json:"type"`
+		Visibility     string `json:"visibility"`
+		EditedAt       string `json:"edited_at,omitempty"`
+
+		Attachments  []PostAttachmentResponse `json:"attachments"`
+		Entities     []PostEntityResponse     `json:"entities"`
+		SharedPost   *SharedPostResponse      `json:"shared_post,omitempty"`
+		Poll         *PollResponse            `json:"poll,omitempty"`
+		Reactions    ReactionSummaryResponse  `json:"reactions"`
+		CommentCount int64                    `json:"comment_count"`
+		ShareCount   int64                    `json:"share_count"`
 	}
 
 	CompanyInfoResponse struct {
-		ID       string `json:"id"`
-		Name     string `json:"name"`
-		About    string `json:"about"`
-		Industry string `json:"industry"`
-		Logo     string `json:"logo"`
-		Headline string `json:"cover"`
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		About         string `json:"about"`
+		Industry      string `json:"industry"`
+		Logo          string `json:"logo"`
+		Headline      string `json:"cover"`
+		FollowerCount int64  `json:"follower_count"`
+		IsFollowing   bool   `json:"is_following"`
+	}
+
+	FollowedCompanyResponse struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Slug       string `json:"slug"`
+		Industry   string `json:"industry"`
+		Logo       string `json:"logo"`
+		FollowedAt string `json:"followed_at"`
 	}
 
 	CompanyJobsResponse struct {
@@ -62,6 +103,7 @@ type (
 		SalaryMin       int                        `json:"min_salary"`
 		SalaryMax       int                        `json:"max_salary"`
 		Status          string                     `json:"status"`
+		Deadline        string                     `json:"deadline,omitempty"`
 		Description     string                     `json:"description"`
 		Skills          []CompanyJobSkillsResponse `json:"skills"`
 		Posted          string                     `json:"posted"`
@@ -100,6 +142,7 @@ type (
 		Description     string `json:"description"`
 		Skills          []string
 		Status          string `json:"status"`
+		Deadline        string `json:"deadline"`
 	}
 
 	CompanyRegisterRequest struct {
@@ -127,6 +170,13 @@ type (
 		SalaryMax       int    `json:"max_salary"`
 		Description     string `json:"description"`
 		Skills          []string
+		Deadline        string `json:"deadline"`
+	}
+
+	// CompanyOpenJobRequest publishes a draft or reopens a job. A job that
+	// expired on its deadline needs a new one.
+	CompanyOpenJobRequest struct {
+		Deadline string `json:"deadline" form:"deadline"`
 	}
 
 	CompanyUpdateProfileRequest struct {
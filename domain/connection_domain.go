@@ -0,0 +1,86 @@
+package domain
+
+import "errors"
+
+const (
+	ConnectionStatusPending   = "pending"
+	ConnectionStatusAccepted  = "accepted"
+	ConnectionStatusRejected  = "rejected"
+	ConnectionStatusWithdrawn = "withdrawn"
+	ConnectionStatusRemoved   = "removed"
+
+	ConnectionDirectionIncoming = "incoming"
+	ConnectionDirectionOutgoing = "outgoing"
+
+	NotificationTypeConnection = "Connection"
+
+	ConnectionDegreeFirst  = 1
+	ConnectionDegreeSecond = 2
+	ConnectionDegreeThird  = 3
+
+	MutualConnectionSampleSize = 3
+)
+
+// ConnectionDegreeLabels maps a connection degree to the label shown on
+// profiles. Everyone beyond the second degree is reported as "3rd+".
+var ConnectionDegreeLabels = map[int]string{
+	ConnectionDegreeFirst:  "1st",
+	ConnectionDegreeSecond: "2nd",
+	ConnectionDegreeThird:  "3rd+",
+}
+
+var (
+	MessageSuccessSendConnection     = "Successfully send connection request"
+	MessageSuccessAcceptConnection   = "Successfully accept connection request"
+	MessageSuccessRejectConnection   = "Successfully reject connection request"
+	MessageSuccessWithdrawConnection = "Successfully withdraw connection request"
+	MessageSuccessRemoveConnection   = "Successfully remove connection"
+	MessageSuccessGetConnections     = "Successfully get connections"
+	MessageSuccessGetConnectionState = "Successfully get connection status"
+
+	MessageFailedSendConnection     = "Failed to send connection request"
+	MessageFailedAcceptConnection   = "Failed to accept connection request"
+	MessageFailedRejectConnection   = "Failed to reject connection request"
+	MessageFailedWithdrawConnection = "Failed to withdraw connection request"
+	MessageFailedRemoveConnection   = "Failed to remove connection"
+	MessageFailedGetConnections     = "Failed to get connections"
+	MessageFailedGetConnectionState = "Failed to get connection status"
+
+	ErrConnectionSelf             = errors.New("cannot connect with yourself")
+	ErrConnectionNotFound         = errors.New("connection not found")
+	ErrConnectionAlreadyExists    = errors.New("already connected")
+	ErrConnectionAlreadyPending   = errors.New("connection request already pending")
+	ErrConnectionInvalidStatus    = errors.New("invalid connection status transition")
+	ErrConnectionTargetNotAllowed = errors.New("target user cannot be connected with")
+	ErrSendConnection             = errors.New("failed to send connection request")
+	ErrUpdateConnection           = errors.New("failed to update connection")
+	ErrGetConnections             = errors.New("failed to get connections")
+)
+
+// ConnectionTransitions lists the statuses a connection may move to from its
+// current status. Rejected, withdrawn and removed connections can be requested
+// again, which starts a fresh pending request.
+var ConnectionTransitions = map[string][]string{
+	ConnectionStatusPending:   {ConnectionStatusAccepted, ConnectionStatusRejected, ConnectionStatusWithdrawn},
+	ConnectionStatusAccepted:  {ConnectionStatusRemoved},
+	ConnectionStatusRejected:  {ConnectionStatusPending},
+	ConnectionStatusWithdrawn: {ConnectionStatusPending},
+	ConnectionStatusRemoved:   {ConnectionStatusPending},
+}
+
+type (
+	ConnectionResponse struct {
+		UserID         string `json:"user_id"`
+		Name           string `json:"name"`
+		Slug           string `json:"slug"`
+		ProfilePicture string `json:"profile_picture"`
+		Headline       string `json:"headline"`
+		Status         string `json:"status"`
+		Since          string `json:"since"`
+	}
+
+	ConnectionStatusResponse struct {
+		Status    string `json:"status"`
+		Direction string `json:"direction"`
+	}
+)
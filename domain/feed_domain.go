@@ -0,0 +1,14 @@
+package domain
+
+const (
+	// FeedGravity is how many seconds of age offset one order of magnitude
+	// of engagement in the ranking score.
+	FeedGravity = 45000
+
+	FeedWeightReaction = 1
+	FeedWeightComment  = 2
+	FeedWeightShare    = 3
+
+	FeedSegmentNetwork = "network"
+	FeedSegmentPopular = "popular"
+)
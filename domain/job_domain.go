@@ -1,6 +1,48 @@
 package domain
 
-import "mime/multipart"
+import (
+	"errors"
+	"mime/multipart"
+	"time"
+)
+
+const (
+	JobSortRelevance  = "relevance"
+	JobSortRecent     = "recent"
+	JobSortSalaryHigh = "salary-high"
+	JobSortSalaryLow  = "salary-low"
+
+	JobStatusDraft   = "draft"
+	JobStatusOpen    = "open"
+	JobStatusPaused  = "paused"
+	JobStatusClosed  = "closed"
+	JobStatusExpired = "expired"
+
+	// JobMaxOpenDuration is how long a job without a deadline stays open
+	// before it is considered stale and expires.
+	JobMaxOpenDuration = 60 * 24 * time.Hour
+
+	// JobExpireInterval is how often jobs past their deadline are expired.
+	JobExpireInterval = 10 * time.Minute
+
+	// Users who saved a job are reminded once it closes within
+	// JobDeadlineReminderWindow, unless they already applied.
+	JobDeadlineReminderWindow   = 48 * time.Hour
+	JobDeadlineReminderInterval = 15 * time.Minute
+	JobDeadlineReminderBatch    = 100
+
+	NotificationTypeSavedJob = "Saved Job"
+)
+
+// JobStatusTransitions lists the statuses a company can move a job to from
+// each status. Jobs only become expired through the scheduler.
+var JobStatusTransitions = map[string][]string{
+	JobStatusDraft:   {JobStatusOpen, JobStatusClosed},
+	JobStatusOpen:    {JobStatusPaused, JobStatusClosed},
+	JobStatusPaused:  {JobStatusOpen, JobStatusClosed},
+	JobStatusClosed:  {JobStatusOpen},
+	JobStatusExpired: {JobStatusOpen, JobStatusClosed},
+}
 
 var (
 	MessageFailedGetJobs                 = "Failed to get jobs"
@@ -9,17 +51,31 @@ var (
 	MessageFailedApplyJob                = "Failed to apply job"
 	MessageFailedGetApplicants           = "Failed to get applicants"
 	MessageFailedChangeApplicationStatus = "Failed to change application status"
+	MessageFailedSaveJob                 = "Failed to save job"
+	MessageFailedUnsaveJob               = "Failed to unsave job"
+	MessageFailedGetSavedJobs            = "Failed to get saved jobs"
 
 	MessageSuccessSearchJobs              = "Successfully search jobs"
 	MessageSuccessGetJobDetail            = "Successfully get job detail"
 	MessageSuccessApplyJob                = "Successfully apply job"
 	MessageSuccessGetApplicants           = "Successfully get applicants"
 	MessageSuccessChangeApplicationStatus = "Successfully change application status"
+	MessageSuccessSaveJob                 = "Successfully save job"
+	MessageSuccessUnsaveJob               = "Successfully unsave job"
+	MessageSuccessGetSavedJobs            = "Successfully get saved jobs"
+
+	ErrJobNotFound     = errors.New("job not found")
+	ErrJobNotOpen      = errors.New("job is not accepting applications")
+	ErrJobAlreadySaved = errors.New("job already saved")
+	ErrJobNotSaved     = errors.New("job not saved")
+	ErrSaveJob         = errors.New("failed to save job")
+	ErrUnsaveJob       = errors.New("failed to unsave job")
+	ErrGetSavedJobs    = errors.New("failed to get saved jobs")
 )
 
 type (
 	JobSearchRequest struct {
-		Title           string `json:"title"`
+		Query           string `json:"q"`
 		JobType         string `json:"job_type"`
 		LocationType    string `json:"location_type"`
 		ExperienceLevel string `json:"experience_level"`
@@ -53,8 +109,19 @@ type (
 		SalaryMax       int      `json:"max_salary"`
 		Description     string   `json:"description"`
 		Status          string   `json:"status"`
+		Deadline        string   `json:"deadline,omitempty"`
 		Posted          string   `json:"posted"`
 		Skills          []string `json:"skills"`
+		IsSaved         bool     `json:"is_saved"`
+
+		Highlights *JobHighlightResponse `json:"highlights,omitempty"`
+	}
+
+	// JobHighlightResponse holds HTML escaped snippets of a search result with
+	// the matched words wrapped in <mark> tags.
+	JobHighlightResponse struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
 	}
 
 	JobDetailResponse struct {
@@ -71,8 +138,32 @@ type (
 		SalaryMax       int      `json:"max_salary"`
 		Description     string   `json:"description"`
 		Status          string   `json:"status"`
+		Deadline        string   `json:"deadline,omitempty"`
 		Posted          string   `json:"posted"`
 		Skills          []string `json:"skills"`
+		IsSaved         bool     `json:"is_saved"`
+	}
+
+	// SavedJobResponse shows the job's current status. A job past its deadline
+	// shows as expired even before the scheduler gets to it, and IsOpen tells
+	// whether it still takes applications.
+	SavedJobResponse struct {
+		ID              string `json:"id"`
+		CompanyName     string `json:"company"`
+		CompanyLogo     string `json:"logo"`
+		CompanySlug     string `json:"company_slug"`
+		Title           string `json:"title"`
+		Location        string `json:"location"`
+		LocationType    string `json:"location_type"`
+		JobType         string `json:"type"`
+		ExperienceLevel string `json:"experience"`
+		SalaryMin       int    `json:"min_salary"`
+		SalaryMax       int    `json:"max_salary"`
+		Status          string `json:"status"`
+		IsOpen          bool   `json:"is_open"`
+		Deadline        string `json:"deadline,omitempty"`
+		Posted          string `json:"posted"`
+		SavedAt         string `json:"saved_at"`
 	}
 
 	JobApplicantResponse struct {
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ReportTargetPost    = "post"
+	ReportTargetComment = "comment"
+	ReportTargetMessage = "message"
+	ReportTargetJob     = "job"
+	ReportTargetProfile = "profile"
+
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonHateSpeech     = "hate_speech"
+	ReportReasonViolence       = "violence"
+	ReportReasonNudity         = "nudity"
+	ReportReasonMisinformation = "misinformation"
+	ReportReasonScam           = "scam"
+	ReportReasonImpersonation  = "impersonation"
+	ReportReasonOther          = "other"
+
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+
+	ModerationActionDismiss   = "dismiss"
+	ModerationActionHide      = "hide"
+	ModerationActionRestore   = "restore"
+	ModerationActionRemove    = "remove"
+	ModerationActionWarn      = "warn"
+	ModerationActionSuspend   = "suspend"
+	ModerationActionUnsuspend = "unsuspend"
+
+	NotificationTypeModeration = "Moderation"
+
+	// SuspensionCheckTTL is how long a user's suspension status is cached by
+	// the auth middleware, so a new suspension takes effect within it.
+	SuspensionCheckTTL = time.Minute
+)
+
+var (
+	MessageSuccessReportContent = "Successfully report content"
+	MessageSuccessGetReports    = "Successfully get reports"
+	MessageSuccessModerate      = "Successfully apply moderation action"
+	MessageSuccessGetActions    = "Successfully get moderation actions"
+
+	MessageFailedReportContent = "Failed to report content"
+	MessageFailedGetReports    = "Failed to get reports"
+	MessageFailedModerate      = "Failed to apply moderation action"
+	MessageFailedGetActions    = "Failed to get moderation actions"
+
+	ErrReportTargetNotFound = errors.New("reported content not found")
+	ErrReportSelf           = errors.New("cannot report your own content")
+	ErrAlreadyReported      = errors.New("content is already reported")
+	ErrReportContent        = errors.New("failed to report content")
+	ErrReportNotFound       = errors.New("report not found")
+	ErrReportClosed         = errors.New("report is already closed")
+	ErrGetReports           = errors.New("failed to get reports")
+	ErrActionNotAllowed     = errors.New("action cannot be applied to this content")
+	ErrDismissWithoutReport = errors.New("only a report can be dismissed")
+	ErrModerationTarget     = errors.New("target_type and target_id are required without a report_id")
+	ErrSuspendDays          = errors.New("suspend_days is required to suspend an account")
+	ErrModerate             = errors.New("failed to apply moderation action")
+	ErrGetModerationActions = errors.New("failed to get moderation actions")
+	ErrAccountSuspended     = errors.New("account is suspended")
+	ErrCheckSuspension      = errors.New("failed to check account status")
+)
+
+type (
+	ReportRequest struct {
+		TargetType string `json:"target_type" form:"target_type" validate:"required,oneof=post comment message job profile"`
+		TargetID   string `json:"target_id" form:"target_id" validate:"required"`
+		Reason     string `json:"reason" form:"reason" validate:"required,oneof=spam harassment hate_speech violence nudity misinformation scam impersonation other"`
+		Details    string `json:"details" form:"details" validate:"required_if=Reason other,max=1000"`
+	}
+
+	// ModerateRequest applies an action to a piece of content or its author.
+	// The target is taken from the report when ReportID is set.
+	ModerateRequest struct {
+		ReportID    string `json:"report_id" form:"report_id"`
+		TargetType  string `json:"target_type" form:"target_type" validate:"omitempty,oneof=post comment message job profile"`
+		TargetID    string `json:"target_id" form:"target_id"`
+		Action      string `json:"action" form:"action" validate:"required,oneof=dismiss hide restore remove warn suspend unsuspend"`
+		SuspendDays int    `json:"suspend_days" form:"suspend_days" validate:"omitempty,min=1,max=3650"`
+		Note        string `json:"note" form:"note" validate:"max=1000"`
+	}
+
+	ReportResponse struct {
+		ID           string `json:"id"`
+		TargetType   string `json:"target_type"`
+		TargetID     string `json:"target_id"`
+		TargetUserID string `json:"target_user_id"`
+		Reason       string `json:"reason"`
+		Details      string `json:"details"`
+		Status       string `json:"status"`
+		ReporterID   string `json:"reporter_id"`
+		ReporterName string `json:"reporter_name"`
+		CreatedAt    string `json:"created_at"`
+	}
+
+	ModerationActionResponse struct {
+		ID           string `json:"id"`
+		ReportID     string `json:"report_id,omitempty"`
+		AdminID      string `json:"admin_id"`
+		AdminName    string `json:"admin_name"`
+		TargetType   string `json:"target_type"`
+		TargetID     string `json:"target_id"`
+		TargetUserID string `json:"target_user_id"`
+		Action       string `json:"action"`
+		Note         string `json:"note"`
+		CreatedAt    string `json:"created_at"`
+	}
+)
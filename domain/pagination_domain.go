@@ -0,0 +1,24 @@
+package domain
+
+import "errors"
+
+const (
+	PaginationDefaultLimit = 20
+	PaginationMaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+type (
+	PaginationRequest struct {
+		Cursor string
+		Limit  int
+	}
+
+	PaginationResponse struct {
+		NextCursor string `json:"next_cursor"`
+		HasMore    bool   `json:"has_more"`
+	}
+)
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PollMaxDuration = 14 * 24 * time.Hour
+
+	// PollCloseInterval is how often closed polls are looked for to send
+	// their results to the author.
+	PollCloseInterval = time.Minute
+	PollCloseBatch    = 100
+
+	NotificationTypePoll = "Poll"
+)
+
+var (
+	MessageSuccessVotePoll = "Successfully vote in poll"
+	MessageSuccessGetPoll  = "Successfully get poll"
+
+	MessageFailedVotePoll = "Failed to vote in poll"
+	MessageFailedGetPoll  = "Failed to get poll"
+
+	ErrPollNotFound            = errors.New("poll not found")
+	ErrPollOptionNotFound      = errors.New("poll option not found")
+	ErrPollClosed              = errors.New("poll is closed")
+	ErrAlreadyVoted            = errors.New("already voted in this poll")
+	ErrVotePoll                = errors.New("failed to vote in poll")
+	ErrGetPoll                 = errors.New("failed to get poll")
+	ErrInvalidPollClosesAt     = errors.New("poll closing time must be an RFC 3339 timestamp")
+	ErrPollDuration            = errors.New("poll must close after the post is published and within 14 days")
+	ErrPollClosesBeforePublish = errors.New("poll would already be closed when the post is published")
+	ErrPollWithAttachments     = errors.New("a post with a poll cannot have attachments")
+)
+
+type (
+	CreatePollRequest struct {
+		Question string   `json:"question" form:"question" validate:"required,max=140"`
+		Options  []string `json:"options" form:"options" validate:"min=2,max=4,unique,dive,required,max=30"`
+		ClosesAt string   `json:"closes_at" form:"closes_at" validate:"required"`
+	}
+
+	VotePollRequest struct {
+		PollID   string `json:"poll_id" form:"poll_id" validate:"required"`
+		OptionID string `json:"option_id" form:"option_id" validate:"required"`
+	}
+
+	// PollResponse leaves out the vote counts until the viewer has voted,
+	// the viewer wrote the poll or the poll has closed.
+	PollResponse struct {
+		ID            string               `json:"id"`
+		Question      string               `json:"question"`
+		ClosesAt      string               `json:"closes_at"`
+		IsClosed      bool                 `json:"is_closed"`
+		HasVoted      bool                 `json:"has_voted"`
+		VotedOptionID string               `json:"voted_option_id,omitempty"`
+		TotalVotes    *int64               `json:"total_votes,omitempty"`
+		Options       []PollOptionResponse `json:"options"`
+	}
+
+	PollOptionResponse struct {
+		ID         string `json:"id"`
+		Text       string `json:"text"`
+		Position   int    `json:"position"`
+		Votes      *int64 `json:"votes,omitempty"`
+		Percentage *int   `json:"percentage,omitempty"`
+	}
+)
@@ -3,36 +3,136 @@ package domain
 import (
 	"errors"
 	"mime/multipart"
+	"time"
 )
 
 var (
-	MessageFailedCreatePost = "Failed to create post"
-	MessageFailedUpdatePost = "Failed to update post"
-	MessageFailedDeletePost = "Failed to delete post"
-	MessageFailedGetFeed    = "Failed to get feed"
+	MessageFailedCreatePost         = "Failed to create post"
+	MessageFailedUpdatePost         = "Failed to update post"
+	MessageFailedDeletePost         = "Failed to delete post"
+	MessageFailedGetFeed            = "Failed to get feed"
+	MessageFailedPublishPost        = "Failed to publish post"
+	MessageFailedSchedulePost       = "Failed to schedule post"
+	MessageFailedCancelSchedule     = "Failed to cancel scheduled post"
+	MessageFailedGetUnpublishedPost = "Failed to get posts"
+	MessageFailedGetPostRevisions   = "Failed to get post revisions"
 
-	MessageSuccessCreatePost = "Successfully create post"
-	MessageSuccessUpdatePost = "Successfully update post"
-	MessageSuccessDeletePost = "Successfully delete post"
-	MessageSuccessGetFeed    = "Successfully get feed"
+	MessageSuccessCreatePost         = "Successfully create post"
+	MessageSuccessUpdatePost         = "Successfully update post"
+	MessageSuccessDeletePost         = "Successfully delete post"
+	MessageSuccessGetFeed            = "Successfully get feed"
+	MessageSuccessPublishPost        = "Successfully publish post"
+	MessageSuccessSchedulePost       = "Successfully schedule post"
+	MessageSuccessCancelSchedule     = "Successfully cancel scheduled post"
+	MessageSuccessGetUnpublishedPost = "Successfully get posts"
+	MessageSuccessGetPostRevisions   = "Successfully get post revisions"
 
 	ErrCreatePost   = errors.New("failed to create post")
 	ErrUpdatePost   = errors.New("failed to update post")
 	ErrDeletePost   = errors.New("failed to delete post")
 	ErrPostNotFound = errors.New("post not found")
 	ErrGetFeed      = errors.New("failed to get feed")
+	ErrPostHidden   = errors.New("post is hidden by a moderator")
+
+	ErrPublishAtInPast      = errors.New("publish time must be in the future")
+	ErrInvalidPublishAt     = errors.New("publish time must be an RFC 3339 timestamp")
+	ErrPostAlreadyPublished = errors.New("post is already published")
+	ErrPostNotScheduled     = errors.New("post is not scheduled")
+	ErrPublishPost          = errors.New("failed to publish post")
+	ErrGetUnpublishedPosts  = errors.New("failed to get posts")
+	ErrGetPostRevisions     = errors.New("failed to get post revisions")
+
+	ErrAttachmentType     = errors.New("attachments must be jpeg, png or gif images, a pdf or an mp4 or webm video")
+	ErrTooManyAttachments = errors.New("too many attachments")
+	ErrMixedAttachments   = errors.New("a document or video must be the only attachment")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrInspectAttachment  = errors.New("failed to read attachment")
+)
+
+const (
+	PostVisibilityPublic      = "public"
+	PostVisibilityConnections = "connections"
+	PostVisibilityFollowers   = "followers"
+
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+
+	// PostPublishInterval is how often due scheduled posts are published.
+	PostPublishInterval = time.Minute
+	PostPublishBatch    = 100
+
+	// PostRevisionFileRetention is how long the files a post was edited away
+	// from stay in its revision history before they are deleted from storage.
+	PostRevisionFileRetention = 90 * 24 * time.Hour
+	// PostRevisionPurgeInterval is how often expired revision files are
+	// looked for.
+	PostRevisionPurgeInterval = time.Hour
+	PostRevisionPurgeBatch    = 100
+
+	AttachmentKindImage    = "image"
+	AttachmentKindDocument = "document"
+	AttachmentKindVideo    = "video"
+
+	PostMaxImages       = 9
+	PostMaxImageSize    = 10 << 20
+	PostMaxDocumentSize = 20 << 20
+	PostMaxVideoSize    = 50 << 20
 )
 
 type (
 	CreatePostRequest struct {
-		Content string                `json:"content" form:"content" validate:"required"`
-		Asset   *multipart.FileHeader `json:"asset" form:"asset"`
+		Content     string                  `json:"content" form:"content" validate:"required"`
+		Visibility  string                  `json:"visibility" form:"visibility" validate:"omitempty,oneof=public connections followers"`
+		IsDraft     bool                    `json:"is_draft" form:"is_draft"`
+		PublishAt   string                  `json:"publish_at" form:"publish_at"`
+		Attachments []*multipart.FileHeader `json:"attachments" form:"attachments"`
+		Poll        *CreatePollRequest      `json:"poll" form:"poll" validate:"omitempty"`
 	}
 
+	SchedulePostRequest struct {
+		ID        string `json:"id" form:"id" validate:"required"`
+		PublishAt string `json:"publish_at" form:"publish_at" validate:"required"`
+	}
+
+	// UpdatePostRequest replaces every attachment of the post when new ones
+	// are sent, and drops them when RemoveAttachments is set.
 	UpdatePostRequest struct {
-		ID      string                `json:"id" form:"id" validate:"required"`
-		Content string                `json:"content" form:"content" validate:"required"`
-		Asset   *multipart.FileHeader `json:"asset" form:"asset"`
+		ID                string                  `json:"id" form:"id" validate:"required"`
+		Content           string                  `json:"content" form:"content" validate:"required"`
+		Visibility        string                  `json:"visibility" form:"visibility" validate:"omitempty,oneof=public connections followers"`
+		Attachments       []*multipart.FileHeader `json:"attachments" form:"attachments"`
+		RemoveAttachments bool                    `json:"remove_attachments" form:"remove_attachments"`
+	}
+
+	UnpublishedPostResponse struct {
+		ID          string                   `json:"id"`
+		Content     string                   `json:"content"`
+		Visibility  string                   `json:"visibility"`
+		Status      string                   `json:"status"`
+		PublishAt   string                   `json:"publish_at,omitempty"`
+		CreatedAt   string                   `json:"created_at"`
+		Attachments []PostAttachmentResponse `json:"attachments"`
+	}
+
+	// PostRevisionResponse is an earlier version of a post. EditedAt is when
+	// it was replaced by the next version.
+	PostRevisionResponse struct {
+		ID          string                   `json:"id"`
+		Content     string                   `json:"content"`
+		Asset       string                   `json:"asset"`
+		EditedAt    string                   `json:"edited_at"`
+		Attachments []PostAttachmentResponse `json:"attachments"`
+	}
+
+	PostAttachmentResponse struct {
+		URL      string `json:"url"`
+		Kind     string `json:"kind"`
+		MimeType string `json:"mime_type"`
+		Size     int64  `json:"size"`
+		Width    int    `json:"width,omitempty"`
+		Height   int    `json:"height,omitempty"`
+		Position int    `json:"position"`
 	}
 
 	PostResponse struct {
@@ -45,5 +145,15 @@ type (
 		CreatedAt      string `json:"created_at"`
 		Slug           string `json:"slug"`
 		Type           string `json:"type"`
+		Visibility     string `json:"visibility"`
+		EditedAt       string `json:"edited_at,omitempty"`
+
+		Attachments  []PostAttachmentResponse `json:"attachments"`
+		Entities     []PostEntityResponse     `json:"entities"`
+		SharedPost   *SharedPostResponse      `json:"shared_post,omitempty"`
+		Poll         *PollResponse            `json:"poll,omitempty"`
+		Reactions    ReactionSummaryResponse  `json:"reactions"`
+		CommentCount int64                    `json:"comment_count"`
+		ShareCount   int64                    `json:"share_count"`
 	}
 )
@@ -0,0 +1,40 @@
+package domain
+
+import "errors"
+
+const (
+	ReactionTypeLike       = "like"
+	ReactionTypeCelebrate  = "celebrate"
+	ReactionTypeInsightful = "insightful"
+	ReactionTypeSupport    = "support"
+
+	NotificationTypeReaction = "Reaction"
+)
+
+var (
+	ReactionTypes = []string{ReactionTypeLike, ReactionTypeCelebrate, ReactionTypeInsightful, ReactionTypeSupport}
+
+	MessageSuccessReactPost   = "Successfully react to post"
+	MessageSuccessUnreactPost = "Successfully remove reaction"
+
+	MessageFailedReactPost   = "Failed to react to post"
+	MessageFailedUnreactPost = "Failed to remove reaction"
+
+	ErrReactPost        = errors.New("failed to react to post")
+	ErrUnreactPost      = errors.New("failed to remove reaction")
+	ErrReactionNotFound = errors.New("reaction not found")
+	ErrGetReactions     = errors.New("failed to get reactions")
+)
+
+type (
+	ReactPostRequest struct {
+		PostID string `json:"post_id" form:"post_id" validate:"required"`
+		Type   string `json:"type" form:"type" validate:"required,oneof=like celebrate insightful support"`
+	}
+
+	ReactionSummaryResponse struct {
+		Total          int64            `json:"total"`
+		Counts         map[string]int64 `json:"counts"`
+		ViewerReaction string           `json:"viewer_reaction"`
+	}
+)
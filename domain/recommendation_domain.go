@@ -0,0 +1,110 @@
+package domain
+
+import "errors"
+
+const (
+	RecommendationWeightMutual   = 3
+	RecommendationWeightEmployer = 2
+	RecommendationWeightSchool   = 2
+	RecommendationWeightSkill    = 1
+
+	RecommendationDefaultLimit = 10
+	RecommendationMaxLimit     = 50
+
+	RecommendationReasonMutual   = "mutual_connections"
+	RecommendationReasonEmployer = "shared_employer"
+	RecommendationReasonSchool   = "shared_school"
+	RecommendationReasonSkill    = "shared_skills"
+
+	// A job's match percentage weighs how many of its skills the viewer has,
+	// how close its experience level is to the viewer's and whether it fits
+	// their location preference. Signals a job says nothing about are left out.
+	JobMatchWeightSkills     = 70
+	JobMatchWeightExperience = 20
+	JobMatchWeightLocation   = 10
+
+	// JobRecommendationCandidatePool is how many of the jobs sharing the most
+	// skills with the viewer are scored.
+	JobRecommendationCandidatePool = 200
+
+	JobMatchReasonSkills     = "skills"
+	JobMatchReasonExperience = "experience_level"
+	JobMatchReasonLocation   = "location"
+
+	JobLocationTypeRemote = "Remote"
+)
+
+// JobExperienceLevels are the experience levels recommendations understand,
+// from junior to senior, with the years of experience each one starts at.
+var JobExperienceLevels = []JobExperienceLevel{
+	{Name: "Internship", MinYears: 0},
+	{Name: "Entry Level", MinYears: 0},
+	{Name: "Associate", MinYears: 2},
+	{Name: "Mid-Senior Level", MinYears: 4},
+	{Name: "Director", MinYears: 8},
+	{Name: "Executive", MinYears: 12},
+}
+
+var (
+	MessageSuccessGetRecommendations = "Successfully get people you may know"
+	MessageFailedGetRecommendations  = "Failed to get people you may know"
+	MessageSuccessGetJobsForYou      = "Successfully get jobs for you"
+	MessageFailedGetJobsForYou       = "Failed to get jobs for you"
+
+	ErrGetRecommendations    = errors.New("failed to get recommendations")
+	ErrGetJobRecommendations = errors.New("failed to get job recommendations")
+)
+
+type (
+	PeopleRecommendationResponse struct {
+		ID              string                         `json:"id"`
+		Name            string                         `json:"name"`
+		Slug            string                         `json:"slug"`
+		ProfilePicture  string                         `json:"profile_picture"`
+		Headline        string                         `json:"headline"`
+		Score           int                            `json:"score"`
+		MutualCount     int                            `json:"mutual_count"`
+		SharedCompanies []string                       `json:"shared_companies"`
+		SharedSchools   []string                       `json:"shared_schools"`
+		SharedSkills    []string                       `json:"shared_skills"`
+		Reasons         []RecommendationReasonResponse `json:"reasons"`
+	}
+
+	JobExperienceLevel struct {
+		Name     string
+		MinYears float64
+	}
+
+	// JobsForYouRequest narrows down the location the viewer is looking for.
+	// Without a location their address is used.
+	JobsForYouRequest struct {
+		Location     string `json:"location"`
+		LocationType string `json:"location_type"`
+		Limit        int    `json:"limit"`
+	}
+
+	JobRecommendationResponse struct {
+		ID              string                         `json:"id"`
+		CompanyName     string                         `json:"company"`
+		CompanyLogo     string                         `json:"logo"`
+		CompanySlug     string                         `json:"company_slug"`
+		Title           string                         `json:"title"`
+		Location        string                         `json:"location"`
+		LocationType    string                         `json:"location_type"`
+		JobType         string                         `json:"type"`
+		ExperienceLevel string                         `json:"experience"`
+		SalaryMin       int                            `json:"min_salary"`
+		SalaryMax       int                            `json:"max_salary"`
+		Deadline        string                         `json:"deadline,omitempty"`
+		Posted          string                         `json:"posted"`
+		MatchPercentage int                            `json:"match_percentage"`
+		MatchedSkills   []string                       `json:"matched_skills"`
+		MissingSkills   []string                       `json:"missing_skills"`
+		Reasons         []RecommendationReasonResponse `json:"reasons"`
+	}
+
+	RecommendationReasonResponse struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+)
@@ -0,0 +1,41 @@
+package domain
+
+import "errors"
+
+const (
+	NotificationTypeShare = "Share"
+
+	SharedPostUnavailableContent = "This content isn't available"
+)
+
+var (
+	MessageSuccessSharePost = "Successfully share post"
+	MessageFailedSharePost  = "Failed to share post"
+
+	ErrSharePost          = errors.New("failed to share post")
+	ErrGetShares          = errors.New("failed to get shares")
+	ErrShareNonPublicPost = errors.New("only public posts can be shared")
+)
+
+type (
+	SharePostRequest struct {
+		PostID     string `json:"post_id" form:"post_id" validate:"required"`
+		Content    string `json:"content" form:"content"`
+		Visibility string `json:"visibility" form:"visibility" validate:"omitempty,oneof=public connections followers"`
+	}
+
+	SharedPostResponse struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Headline       string `json:"headline"`
+		ProfilePicture string `json:"profile_picture"`
+		Content        string `json:"content"`
+		Asset          string `json:"asset"`
+		CreatedAt      string `json:"created_at"`
+		Slug           string `json:"slug"`
+		Type           string `json:"type"`
+		IsUnavailable  bool   `json:"is_unavailable"`
+
+		Attachments []PostAttachmentResponse `json:"attachments,omitempty"`
+	}
+)
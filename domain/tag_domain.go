@@ -0,0 +1,51 @@
+package domain
+
+import "errors"
+
+const (
+	PostEntityHashtag = "hashtag"
+	PostEntityMention = "mention"
+
+	MentionTypeUser    = "user"
+	MentionTypeCompany = "company"
+
+	NotificationTypeMention = "Mention"
+
+	HashtagMaxLength = 64
+)
+
+var (
+	MessageSuccessFollowHashtag   = "Successfully follow hashtag"
+	MessageSuccessUnfollowHashtag = "Successfully unfollow hashtag"
+	MessageSuccessGetFollowedTags = "Successfully get followed hashtags"
+	MessageSuccessGetHashtagFeed  = "Successfully get hashtag feed"
+
+	MessageFailedFollowHashtag   = "Failed to follow hashtag"
+	MessageFailedUnfollowHashtag = "Failed to unfollow hashtag"
+	MessageFailedGetFollowedTags = "Failed to get followed hashtags"
+	MessageFailedGetHashtagFeed  = "Failed to get hashtag feed"
+
+	ErrInvalidHashtag     = errors.New("invalid hashtag")
+	ErrHashtagNotFollowed = errors.New("hashtag is not followed")
+	ErrFollowHashtag      = errors.New("failed to follow hashtag")
+	ErrUnfollowHashtag    = errors.New("failed to unfollow hashtag")
+	ErrGetFollowedTags    = errors.New("failed to get followed hashtags")
+	ErrTagPost            = errors.New("failed to save post hashtags and mentions")
+)
+
+type (
+	// PostEntityResponse marks a hashtag or mention inside post content.
+	// Offset and Length count Unicode code points.
+	PostEntityResponse struct {
+		Type        string `json:"type"`
+		Offset      int    `json:"offset"`
+		Length      int    `json:"length"`
+		Value       string `json:"value"`
+		MentionType string `json:"mention_type,omitempty"`
+	}
+
+	HashtagResponse struct {
+		Name          string `json:"name"`
+		FollowedSince string `json:"followed_since"`
+	}
+)
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TrendingWindowHour = "1h"
+	TrendingWindowDay  = "24h"
+	TrendingWindowWeek = "7d"
+
+	TrendingKindHashtag = "hashtag"
+	TrendingKindCompany = "company"
+
+	// TrendingInterval is how often the trending summaries are recomputed.
+	TrendingInterval = 5 * time.Minute
+	TrendingLimit    = 20
+
+	// TrendingWeightPost is what a new post adds to a topic's score, on the
+	// same scale as the feed's engagement weights.
+	TrendingWeightPost = 5
+)
+
+// TrendingWindows maps each rolling window to how far back it looks.
+var TrendingWindows = map[string]time.Duration{
+	TrendingWindowHour: time.Hour,
+	TrendingWindowDay:  24 * time.Hour,
+	TrendingWindowWeek: 7 * 24 * time.Hour,
+}
+
+var (
+	MessageSuccessGetTrending = "Successfully get trending topics"
+	MessageFailedGetTrending  = "Failed to get trending topics"
+
+	ErrInvalidTrendingWindow = errors.New("window must be one of 1h, 24h or 7d")
+	ErrGetTrending           = errors.New("failed to get trending topics")
+)
+
+type (
+	// TrendingResponse lists the hashtags and the topics, the company
+	// accounts people mention, that are picking up in public posts.
+	TrendingResponse struct {
+		Window    string                    `json:"window"`
+		UpdatedAt string                    `json:"updated_at,omitempty"`
+		Hashtags  []TrendingHashtagResponse `json:"hashtags"`
+		Topics    []TrendingTopicResponse   `json:"topics"`
+	}
+
+	TrendingHashtagResponse struct {
+		Name       string `json:"name"`
+		Rank       int    `json:"rank"`
+		PostCount  int64  `json:"post_count"`
+		Engagement int64  `json:"engagement"`
+	}
+
+	TrendingTopicResponse struct {
+		Type           string `json:"type"`
+		Name           string `json:"name"`
+		Slug           string `json:"slug"`
+		ProfilePicture string `json:"profile_picture"`
+		Rank           int    `json:"rank"`
+		PostCount      int64  `json:"post_count"`
+		Engagement     int64  `json:"engagement"`
+	}
+)
@@ -20,6 +20,7 @@ var (
 	MessageSuccessAddSkill             = "add skill success"
 	MessageSuccessSearchUser           = "search user success"
 	MessageSuccessGetSkills            = "get skills success"
+	MessageSuccessGetProfileViews      = "get profile views success"
 
 	MessageFailedBodyRequest      = "body request failed"
 	MessageFailedRegister         = "register failed"
@@ -34,6 +35,7 @@ var (
 	MessageFailedAddSkill         = "failed add skill"
 	MessageFailedSearchUser       = "failed search user"
 	MessageFailedGetSkills        = "failed get skills"
+	MessageFailedGetProfileViews  = "failed get profile views"
 
 	ErrAccountAlreadyVerified = errors.New("account already verified")
 	ErrEmailAlreadyExists     = errors.New("email already exists")
@@ -55,15 +57,38 @@ var (
 	ErrSearchUser             = errors.New("search user failed")
 	ErrGetSkills              = errors.New("get skills failed")
 	ErrPasswordNotValid       = errors.New("password not valid")
+	ErrGetProfileViews        = errors.New("get profile views failed")
+)
+
+const (
+	ProfileViewsDefaultDays = 30
+	ProfileViewsMaxDays     = 90
+	ProfileViewersLimit     = 20
 )
 
 type (
 	UserProfileResponse struct {
-		PersonalInfo UserPersonalInfoResponse  `json:"personal_info"`
-		Educations   []UserEducationsResponse  `json:"educations"`
-		Experiences  []UserExperiencesResponse `json:"experiences"`
-		Skills       []UserSkillsResponse      `json:"skills"`
-		Posts        []UserPostsResponse       `json:"posts"`
+		PersonalInfo UserPersonalInfoResponse    `json:"personal_info"`
+		Educations   []UserEducationsResponse    `json:"educations"`
+		Experiences  []UserExperiencesResponse   `json:"experiences"`
+		Skills       []UserSkillsResponse        `json:"skills"`
+		Posts        []UserPostsResponse         `json:"posts"`
+		Relationship *ConnectionRelationResponse `json:"relationship,omitempty"`
+	}
+
+	ConnectionRelationResponse struct {
+		Degree            int                        `json:"degree"`
+		DegreeLabel       string                     `json:"degree_label"`
+		MutualCount       int                        `json:"mutual_count"`
+		MutualConnections []MutualConnectionResponse `json:"mutual_connections"`
+	}
+
+	MutualConnectionResponse struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Slug           string `json:"slug"`
+		ProfilePicture string `json:"profile_picture"`
+		Headline       string `json:"headline"`
 	}
 
 	UserPostsResponse struct {
@@ -76,6 +101,12 @@ type (
 		Asset          string `json:"asset"`
 		Slug           string `json:"slug"`
 		Type           string `json:"type"`
+		Visibility     string `json:"visibility"`
+		EditedAt       string `json:"edited_at,omitempty"`
+
+		Attachments []PostAttachmentResponse `json:"attachments"`
+		Entities    []PostEntityResponse     `json:"entities"`
+		SharedPost  *SharedPostResponse      `json:"shared_post,omitempty"`
 	}
 
 	UserPersonalInfoResponse struct {
@@ -208,12 +239,36 @@ type (
 	}
 
 	UserSearchResponse struct {
+		ID             string                      `json:"id"`
+		Name           string                      `json:"name"`
+		Slug           string                      `json:"slug"`
+		Type           string                      `json:"type"`
+		ProfilePicture string                      `json:"profile_picture"`
+		Headline       string                      `json:"headline"`
+		Relationship   *ConnectionRelationResponse `json:"relationship,omitempty"`
+	}
+
+	ProfileViewsResponse struct {
+		TotalViews    int64                      `json:"total_views"`
+		UniqueViewers int64                      `json:"unique_viewers"`
+		Days          int                        `json:"days"`
+		Daily         []ProfileViewCountResponse `json:"daily"`
+		ViewersLocked bool                       `json:"viewers_locked"`
+		RecentViewers []ProfileViewerResponse    `json:"recent_viewers"`
+	}
+
+	ProfileViewCountResponse struct {
+		Date  string `json:"date"`
+		Count int64  `json:"count"`
+	}
+
+	ProfileViewerResponse struct {
 		ID             string `json:"id"`
 		Name           string `json:"name"`
 		Slug           string `json:"slug"`
-		Type           string `json:"type"`
 		ProfilePicture string `json:"profile_picture"`
 		Headline       string `json:"headline"`
+		ViewedAt       string `json:"viewed_at"`
 	}
 
 	SkillsResponse struct {
@@ -0,0 +1,13 @@
+package entities
+
+import "github.com/google/uuid"
+
+type CompanyFollower struct {
+	CompanyID uuid.UUID `gorm:"type:uuid;primary_key" json:"company_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key;index" json:"user_id"`
+
+	Company *Companies `gorm:"foreignKey:CompanyID;constraint:OnDelete:CASCADE"`
+	User    *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+
+	Timestamp
+}
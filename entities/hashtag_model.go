@@ -0,0 +1,27 @@
+package entities
+
+import "github.com/google/uuid"
+
+type Hashtag struct {
+	ID   uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Name string    `gorm:"uniqueIndex;not null" json:"name"`
+	Timestamp
+}
+
+type PostHashtag struct {
+	PostID    uuid.UUID `gorm:"type:uuid;primary_key" json:"post_id"`
+	HashtagID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"hashtag_id"`
+
+	Post    *Post    `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Hashtag *Hashtag `gorm:"foreignKey:HashtagID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
+
+type HashtagFollower struct {
+	HashtagID uuid.UUID `gorm:"type:uuid;primary_key" json:"hashtag_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key;index" json:"user_id"`
+
+	Hashtag *Hashtag `gorm:"foreignKey:HashtagID;constraint:OnDelete:CASCADE"`
+	User    *User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
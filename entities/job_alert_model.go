@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobAlert is a saved job search. Matching jobs opened since LastRunAt that
+// were not sent yet go out when NextRunAt comes around. LastRunAt only moves
+// up to the newest job that was sent.
+type JobAlert struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID          uuid.UUID `gorm:"type:uuid;index" json:"user_id"`
+	Name            string    `json:"name"`
+	Query           string    `json:"query"`
+	JobType         string    `json:"job_type"`
+	LocationType    string    `json:"location_type"`
+	ExperienceLevel string    `json:"experience_level"`
+	MinSalary       int       `json:"min_salary"`
+	MaxSalary       int       `json:"max_salary"`
+	Frequency       string    `json:"frequency"`
+	LastRunAt       time.Time `gorm:"type:timestamp" json:"last_run_at"`
+	NextRunAt       time.Time `gorm:"type:timestamp;index" json:"next_run_at"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
+
+// JobAlertDelivery records that a job was sent to a user, so a job matching
+// several of their alerts is only sent once.
+type JobAlertDelivery struct {
+	UserID  uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	JobID   uuid.UUID `gorm:"type:uuid;primary_key" json:"job_id"`
+	AlertID uuid.UUID `gorm:"type:uuid;index" json:"alert_id"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Job  *Job  `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
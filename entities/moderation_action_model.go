@@ -0,0 +1,20 @@
+package entities
+
+import "github.com/google/uuid"
+
+// ModerationAction records a decision taken by an admin. Actions are never
+// updated or deleted, so together they form the moderation history.
+type ModerationAction struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	AdminID      uuid.UUID  `gorm:"type:uuid;index" json:"admin_id"`
+	ReportID     *uuid.UUID `gorm:"type:uuid;index" json:"report_id"`
+	TargetType   string     `gorm:"index:idx_moderation_target" json:"target_type"`
+	TargetID     uuid.UUID  `gorm:"type:uuid;index:idx_moderation_target" json:"target_id"`
+	TargetUserID uuid.UUID  `gorm:"type:uuid;index" json:"target_user_id"`
+	Action       string     `json:"action"`
+	Note         string     `json:"note"`
+
+	Admin  *User   `gorm:"foreignKey:AdminID"`
+	Report *Report `gorm:"foreignKey:ReportID"`
+	Timestamp
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Poll struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PostID            uuid.UUID  `gorm:"type:uuid;uniqueIndex" json:"post_id"`
+	Question          string     `json:"question"`
+	ClosesAt          time.Time  `gorm:"type:timestamp;index" json:"closes_at"`
+	ResultsNotifiedAt *time.Time `gorm:"type:timestamp" json:"results_notified_at"`
+
+	Post    *Post        `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Options []PollOption `gorm:"foreignKey:PollID"`
+	Timestamp
+}
+
+type PollOption struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PollID   uuid.UUID `gorm:"type:uuid;index" json:"poll_id"`
+	Text     string    `json:"text"`
+	Position int       `json:"position"`
+
+	Poll *Poll `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
+
+// PollVote is a user's single choice in a poll. The unique index on poll and
+// user is what guarantees one vote per user.
+type PollVote struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PollID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_poll_vote_user" json:"poll_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_poll_vote_user" json:"user_id"`
+	OptionID uuid.UUID `gorm:"type:uuid;index" json:"option_id"`
+
+	Poll   *Poll       `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE"`
+	User   *User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Option *PollOption `gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostImpression counts how often a post was served to a viewer in a feed
+// on one day. Signed out viewers are counted under uuid.Nil.
+type PostImpression struct {
+	PostID   uuid.UUID `gorm:"type:uuid;primary_key" json:"post_id"`
+	ViewerID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"viewer_id"`
+	ViewedOn time.Time `gorm:"type:date;primary_key" json:"viewed_on"`
+	Count    int64     `json:"count"`
+
+	Post *Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+
+	Timestamp
+}
+
+type PostProfileClick struct {
+	PostID    uuid.UUID `gorm:"type:uuid;primary_key" json:"post_id"`
+	ViewerID  uuid.UUID `gorm:"type:uuid;primary_key;index" json:"viewer_id"`
+	ClickedOn time.Time `gorm:"type:date;primary_key" json:"clicked_on"`
+
+	Post   *Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Viewer *User `gorm:"foreignKey:ViewerID;constraint:OnDelete:CASCADE"`
+
+	Timestamp
+}
@@ -0,0 +1,18 @@
+package entities
+
+import "github.com/google/uuid"
+
+type PostAttachment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PostID    uuid.UUID `gorm:"type:uuid;index" json:"post_id"`
+	ObjectKey string    `json:"object_key"`
+	URL       string    `json:"url"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Position  int       `json:"position"`
+
+	Post *Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PostComment struct {
+	ID       uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PostID   uuid.UUID  `gorm:"type:uuid;index" json:"post_id"`
+	UserID   uuid.UUID  `gorm:"type:uuid;index" json:"user_id"`
+	ParentID *uuid.UUID `gorm:"type:uuid;index" json:"parent_id"`
+	Content  string     `json:"content"`
+	EditedAt *time.Time `gorm:"type:timestamp" json:"edited_at"`
+	HiddenAt *time.Time `gorm:"type:timestamp" json:"hidden_at"`
+
+	Post   *Post        `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	User   *User        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Parent *PostComment `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "github.com/google/uuid"
+
+type PostMention struct {
+	PostID uuid.UUID `gorm:"type:uuid;primary_key" json:"post_id"`
+	Slug   string    `gorm:"primary_key" json:"slug"`
+	UserID uuid.UUID `gorm:"type:uuid;index" json:"user_id"`
+	Type   string    `json:"type"`
+
+	Post *Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
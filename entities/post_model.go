@@ -1,13 +1,27 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Post struct {
-	ID      uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	UserID  uuid.UUID `json:"user_id"`
-	Asset   string    `json:"asset"`
-	Content string    `json:"content"`
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Asset        string     `json:"asset"`
+	Content      string     `json:"content"`
+	SharedPostID *uuid.UUID `gorm:"type:uuid;index" json:"shared_post_id"`
+	Visibility   string     `gorm:"default:public;index" json:"visibility"`
+	Status       string     `gorm:"default:published;index" json:"status"`
+	PublishAt    *time.Time `gorm:"type:timestamp;index" json:"publish_at"`
+	EditedAt     *time.Time `gorm:"type:timestamp" json:"edited_at"`
+	HiddenAt     *time.Time `gorm:"type:timestamp" json:"hidden_at"`
 
-	User *User `gorm:"foreignKey:UserID"`
+	User        *User            `gorm:"foreignKey:UserID"`
+	SharedPost  *Post            `gorm:"foreignKey:SharedPostID"`
+	Mentions    []PostMention    `gorm:"foreignKey:PostID"`
+	Attachments []PostAttachment `gorm:"foreignKey:PostID"`
+	Poll        *Poll            `gorm:"foreignKey:PostID"`
 	Timestamp
 }
@@ -0,0 +1,13 @@
+package entities
+
+import "github.com/google/uuid"
+
+type PostReaction struct {
+	PostID uuid.UUID `gorm:"type:uuid;primary_key" json:"post_id"`
+	UserID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"user_id"`
+	Type   string    `json:"type"`
+
+	Post *Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "github.com/google/uuid"
+
+// PostRevision is a snapshot of a post as it was before an edit. Revisions
+// are only ever inserted; CreatedAt is the time the edit replaced it.
+type PostRevision struct {
+	ID      uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	PostID  uuid.UUID `gorm:"type:uuid;index" json:"post_id"`
+	Content string    `json:"content"`
+	Asset   string    `json:"asset"`
+
+	Post        *Post                    `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Attachments []PostRevisionAttachment `gorm:"foreignKey:RevisionID"`
+	Timestamp
+}
+
+type PostRevisionAttachment struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	RevisionID uuid.UUID `gorm:"type:uuid;index" json:"revision_id"`
+	ObjectKey  string    `json:"object_key"`
+	URL        string    `json:"url"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Position   int       `json:"position"`
+
+	Revision *PostRevision `gorm:"foreignKey:RevisionID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
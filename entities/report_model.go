@@ -0,0 +1,18 @@
+package entities
+
+import "github.com/google/uuid"
+
+type Report struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	ReporterID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_report_reporter_target" json:"reporter_id"`
+	TargetType   string    `gorm:"uniqueIndex:idx_report_reporter_target;index:idx_report_target" json:"target_type"`
+	TargetID     uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_report_reporter_target;index:idx_report_target" json:"target_id"`
+	TargetUserID uuid.UUID `gorm:"type:uuid;index" json:"target_user_id"`
+	Reason       string    `json:"reason"`
+	Details      string    `json:"details"`
+	Status       string    `gorm:"default:open;index" json:"status"`
+
+	Reporter   *User `gorm:"foreignKey:ReporterID;constraint:OnDelete:CASCADE"`
+	TargetUser *User `gorm:"foreignKey:TargetUserID;constraint:OnDelete:CASCADE"`
+	Timestamp
+}
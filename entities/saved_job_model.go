@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedJob is a job a user bookmarked. RemindedDeadline is the deadline the
+// user was last reminded of, so a job reopened with a new deadline gets a new
+// reminder.
+type SavedJob struct {
+	UserID           uuid.UUID  `gorm:"type:uuid;primary_key" json:"user_id"`
+	JobID            uuid.UUID  `gorm:"type:uuid;primary_key;index" json:"job_id"`
+	RemindedDeadline *time.Time `gorm:"type:timestamp" json:"reminded_deadline"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Job  *Job  `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+
+	Timestamp
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrendingTopic is one ranked row of a precomputed trending summary. Each
+// row points at either a hashtag or a mentioned account, depending on Kind.
+type TrendingTopic struct {
+	Window     string     `gorm:"column:trend_window;primary_key" json:"window"`
+	Kind       string     `gorm:"primary_key" json:"kind"`
+	Rank       int        `gorm:"primary_key;autoIncrement:false" json:"rank"`
+	HashtagID  *uuid.UUID `gorm:"type:uuid" json:"hashtag_id"`
+	UserID     *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	PostCount  int64      `json:"post_count"`
+	Engagement int64      `json:"engagement"`
+	Score      int64      `json:"score"`
+	ComputedAt time.Time  `gorm:"type:timestamp" json:"computed_at"`
+
+	Hashtag *Hashtag `gorm:"foreignKey:HashtagID;constraint:OnDelete:CASCADE"`
+	User    *User    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
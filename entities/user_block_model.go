@@ -0,0 +1,14 @@
+package entities
+
+import "github.com/google/uuid"
+
+type UserBlock struct {
+	UserID       uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	TargetUserID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"target_user_id"`
+	Type         string    `gorm:"primary_key" json:"type"`
+
+	User       *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TargetUser *User `gorm:"foreignKey:TargetUserID;constraint:OnDelete:CASCADE"`
+
+	Timestamp
+}
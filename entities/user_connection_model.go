@@ -4,8 +4,8 @@ import "github.com/google/uuid"
 
 type UserConnection struct {
 	UserID          uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
-	ConnectedWithID uuid.UUID `gorm:"type:uuid;primary_key" json:"connected_with_id"`
-	Status          string    `json:"status"`
+	ConnectedWithID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"connected_with_id"`
+	Status          string    `gorm:"index" json:"status"`
 
 	User       *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 	Connection *User `gorm:"foreignKey:ConnectedWithID;constraint:OnDelete:CASCADE"`
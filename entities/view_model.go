@@ -1,10 +1,15 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Views struct {
 	UserID   uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
-	ViewerID uuid.UUID `gorm:"type:uuid;primary_key" json:"viewer_id"`
+	ViewerID uuid.UUID `gorm:"type:uuid;primary_key;index" json:"viewer_id"`
+	ViewedOn time.Time `gorm:"type:date;primary_key" json:"viewed_on"`
 
 	User   *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 	Viewer *User `gorm:"foreignKey:ViewerID;constraint:OnDelete:CASCADE"`
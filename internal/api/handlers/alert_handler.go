@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/alert"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	AlertHandler interface {
+		CreateAlert(c *fiber.Ctx) error
+		GetAlerts(c *fiber.Ctx) error
+		UpdateAlert(c *fiber.Ctx) error
+		DeleteAlert(c *fiber.Ctx) error
+	}
+
+	alertHandler struct {
+		AlertService alert.AlertService
+		Validator    *validator.Validate
+	}
+)
+
+func NewAlertHandler(alertService alert.AlertService, validator *validator.Validate) AlertHandler {
+	return &alertHandler{
+		AlertService: alertService,
+		Validator:    validator,
+	}
+}
+
+func (h *alertHandler) CreateAlert(c *fiber.Ctx) error {
+	var req domain.JobAlertRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedCreateJobAlert, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.AlertService.CreateAlert(c.Context(), req, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedCreateJobAlert, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusCreated, domain.MessageSuccessCreateJobAlert)
+}
+
+func (h *alertHandler) GetAlerts(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.AlertService.GetAlerts(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetJobAlerts, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetJobAlerts)
+}
+
+func (h *alertHandler) UpdateAlert(c *fiber.Ctx) error {
+	var req domain.JobAlertRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUpdateJobAlert, err)
+	}
+
+	alertID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.AlertService.UpdateAlert(c.Context(), alertID, req, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUpdateJobAlert, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessUpdateJobAlert)
+}
+
+func (h *alertHandler) DeleteAlert(c *fiber.Ctx) error {
+	alertID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.AlertService.DeleteAlert(c.Context(), alertID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedDeleteJobAlert, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessDeleteJobAlert)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/analytics"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	AnalyticsHandler interface {
+		GetPostAnalytics(c *fiber.Ctx) error
+		RecordProfileClick(c *fiber.Ctx) error
+	}
+
+	analyticsHandler struct {
+		AnalyticsService analytics.AnalyticsService
+		Validator        *validator.Validate
+	}
+)
+
+func NewAnalyticsHandler(analyticsService analytics.AnalyticsService, validator *validator.Validate) AnalyticsHandler {
+	return &analyticsHandler{
+		AnalyticsService: analyticsService,
+		Validator:        validator,
+	}
+}
+
+func (h *analyticsHandler) GetPostAnalytics(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	days := c.QueryInt("days", domain.PostAnalyticsDefaultDays)
+
+	res, err := h.AnalyticsService.GetPostAnalytics(c.Context(), postID, userID, days)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetPostAnalytics, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetPostAnalytics)
+}
+
+func (h *analyticsHandler) RecordProfileClick(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.AnalyticsService.RecordProfileClick(c.Context(), postID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedRecordProfileClick, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessRecordProfileClick)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/block"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	BlockHandler interface {
+		BlockUser(c *fiber.Ctx) error
+		UnblockUser(c *fiber.Ctx) error
+		MuteUser(c *fiber.Ctx) error
+		UnmuteUser(c *fiber.Ctx) error
+		GetBlockedUsers(c *fiber.Ctx) error
+		GetMutedUsers(c *fiber.Ctx) error
+	}
+
+	blockHandler struct {
+		BlockService block.BlockService
+		Validator    *validator.Validate
+	}
+)
+
+func NewBlockHandler(blockService block.BlockService, validator *validator.Validate) BlockHandler {
+	return &blockHandler{
+		BlockService: blockService,
+		Validator:    validator,
+	}
+}
+
+func (h *blockHandler) BlockUser(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.BlockService.BlockUser(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBlockUser, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessBlockUser)
+}
+
+func (h *blockHandler) UnblockUser(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.BlockService.UnblockUser(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnblockUser, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnblockUser)
+}
+
+func (h *blockHandler) MuteUser(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.BlockService.MuteUser(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedMuteUser, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessMuteUser)
+}
+
+func (h *blockHandler) UnmuteUser(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.BlockService.UnmuteUser(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnmuteUser, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnmuteUser)
+}
+
+func (h *blockHandler) GetBlockedUsers(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.BlockService.GetBlockedUsers(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetBlockedUsers, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetBlockedUsers)
+}
+
+func (h *blockHandler) GetMutedUsers(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.BlockService.GetMutedUsers(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetMutedUsers, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetMutedUsers)
+}
@@ -84,11 +84,11 @@ func (h *chatHandler) GetMessages(c *fiber.Ctx) error {
 	roomID := c.Params("id")
 	userID := c.Locals("user_id").(string)
 
-	res, err := h.ChatService.GetMessages(c.Context(), userID, roomID)
+	res, meta, err := h.ChatService.GetMessages(c.Context(), userID, roomID, getPagination(c))
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetMessages, err)
 	}
 
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetMessages)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessGetMessages)
 }
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/comment"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	CommentHandler interface {
+		CreateComment(c *fiber.Ctx) error
+		UpdateComment(c *fiber.Ctx) error
+		DeleteComment(c *fiber.Ctx) error
+		GetComments(c *fiber.Ctx) error
+		GetReplies(c *fiber.Ctx) error
+	}
+
+	commentHandler struct {
+		CommentService comment.CommentService
+		Validator      *validator.Validate
+	}
+)
+
+func NewCommentHandler(commentService comment.CommentService, validator *validator.Validate) CommentHandler {
+	return &commentHandler{
+		CommentService: commentService,
+		Validator:      validator,
+	}
+}
+
+func (h *commentHandler) CreateComment(c *fiber.Ctx) error {
+	req := new(domain.CreateCommentRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedCreateComment, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.CommentService.CreateComment(c.Context(), *req, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedCreateComment, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusCreated, domain.MessageSuccessCreateComment)
+}
+
+func (h *commentHandler) UpdateComment(c *fiber.Ctx) error {
+	req := new(domain.UpdateCommentRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUpdateComment, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	if err := h.CommentService.UpdateComment(c.Context(), *req, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUpdateComment, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUpdateComment)
+}
+
+func (h *commentHandler) DeleteComment(c *fiber.Ctx) error {
+	commentID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.CommentService.DeleteComment(c.Context(), commentID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedDeleteComment, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessDeleteComment)
+}
+
+func (h *commentHandler) GetComments(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	viewerID, _ := c.Locals("user_id").(string)
+
+	comments, meta, err := h.CommentService.GetComments(c.Context(), postID, viewerID, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetComments, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, comments, meta, fiber.StatusOK, domain.MessageSuccessGetComments)
+}
+
+func (h *commentHandler) GetReplies(c *fiber.Ctx) error {
+	commentID := c.Params("id")
+	viewerID, _ := c.Locals("user_id").(string)
+
+	replies, meta, err := h.CommentService.GetReplies(c.Context(), commentID, viewerID, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetComments, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, replies, meta, fiber.StatusOK, domain.MessageSuccessGetComments)
+}
@@ -17,10 +17,16 @@ type (
 		GetProfile(c *fiber.Ctx) error
 		AddJob(c *fiber.Ctx) error
 		UpdateJob(c *fiber.Ctx) error
+		OpenJob(c *fiber.Ctx) error
+		PauseJob(c *fiber.Ctx) error
+		CloseJob(c *fiber.Ctx) error
 		UpdateProfile(c *fiber.Ctx) error
 		RegisterCompany(c *fiber.Ctx) error
 		LoginCompany(c *fiber.Ctx) error
 		GetListCompany(c *fiber.Ctx) error
+		FollowCompany(c *fiber.Ctx) error
+		UnfollowCompany(c *fiber.Ctx) error
+		GetFollowedCompanies(c *fiber.Ctx) error
 	}
 	companyHandler struct {
 		CompanyService company.CompanyService
@@ -36,13 +42,13 @@ func NewCompanyHandler(companyService company.CompanyService, validator *validat
 }
 
 func (h *companyHandler) GetListCompany(c *fiber.Ctx) error {
-	res, err := h.CompanyService.GetListCompany(c.Context())
+	res, meta, err := h.CompanyService.GetListCompany(c.Context(), getPagination(c))
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetListCompany, err)
 	}
 
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetListCompany)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessGetListCompany)
 }
 
 func (h *companyHandler) LoginCompany(c *fiber.Ctx) error {
@@ -87,8 +93,9 @@ func (h *companyHandler) RegisterCompany(c *fiber.Ctx) error {
 
 func (h *companyHandler) GetProfile(c *fiber.Ctx) error {
 	slug := c.Params("slug")
+	viewerID, _ := c.Locals("user_id").(string)
 
-	res, err := h.CompanyService.GetProfile(c.Context(), slug)
+	res, err := h.CompanyService.GetProfile(c.Context(), slug, viewerID)
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetProfile, err)
@@ -141,6 +148,53 @@ func (h *companyHandler) UpdateJob(c *fiber.Ctx) error {
 	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessAddJob)
 }
 
+func (h *companyHandler) OpenJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+
+	var req domain.CompanyOpenJobRequest
+
+	// The deadline is optional, so the body may be left out.
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+		}
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedChangeJobStatus, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	if err := h.CompanyService.OpenJob(c.Context(), jobID, req, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedChangeJobStatus, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessChangeJobStatus)
+}
+
+func (h *companyHandler) PauseJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.CompanyService.PauseJob(c.Context(), jobID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedChangeJobStatus, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessChangeJobStatus)
+}
+
+func (h *companyHandler) CloseJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.CompanyService.CloseJob(c.Context(), jobID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedChangeJobStatus, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessChangeJobStatus)
+}
+
 func (h *companyHandler) UpdateProfile(c *fiber.Ctx) error {
 	var req domain.CompanyUpdateProfileRequest
 
@@ -165,3 +219,41 @@ func (h *companyHandler) UpdateProfile(c *fiber.Ctx) error {
 
 	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessUpdateProfileCompany)
 }
+
+func (h *companyHandler) FollowCompany(c *fiber.Ctx) error {
+	companyID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	err := h.CompanyService.FollowCompany(c.Context(), companyID, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedFollowCompany, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessFollowCompany)
+}
+
+func (h *companyHandler) UnfollowCompany(c *fiber.Ctx) error {
+	companyID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	err := h.CompanyService.UnfollowCompany(c.Context(), companyID, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnfollowCompany, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnfollowCompany)
+}
+
+func (h *companyHandler) GetFollowedCompanies(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.CompanyService.GetFollowedCompanies(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetFollowedCompanies, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetFollowedCompanies)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/connection"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	ConnectionHandler interface {
+		SendRequest(c *fiber.Ctx) error
+		AcceptRequest(c *fiber.Ctx) error
+		RejectRequest(c *fiber.Ctx) error
+		WithdrawRequest(c *fiber.Ctx) error
+		RemoveConnection(c *fiber.Ctx) error
+		GetConnections(c *fiber.Ctx) error
+		GetIncomingRequests(c *fiber.Ctx) error
+		GetOutgoingRequests(c *fiber.Ctx) error
+		GetConnectionStatus(c *fiber.Ctx) error
+	}
+
+	connectionHandler struct {
+		ConnectionService connection.ConnectionService
+		Validator         *validator.Validate
+	}
+)
+
+func NewConnectionHandler(connectionService connection.ConnectionService, validator *validator.Validate) ConnectionHandler {
+	return &connectionHandler{
+		ConnectionService: connectionService,
+		Validator:         validator,
+	}
+}
+
+func (h *connectionHandler) SendRequest(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ConnectionService.SendRequest(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSendConnection, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessSendConnection)
+}
+
+func (h *connectionHandler) AcceptRequest(c *fiber.Ctx) error {
+	requesterID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ConnectionService.AcceptRequest(c.Context(), userID, requesterID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedAcceptConnection, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessAcceptConnection)
+}
+
+func (h *connectionHandler) RejectRequest(c *fiber.Ctx) error {
+	requesterID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ConnectionService.RejectRequest(c.Context(), userID, requesterID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedRejectConnection, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessRejectConnection)
+}
+
+func (h *connectionHandler) WithdrawRequest(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ConnectionService.WithdrawRequest(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedWithdrawConnection, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessWithdrawConnection)
+}
+
+func (h *connectionHandler) RemoveConnection(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ConnectionService.RemoveConnection(c.Context(), userID, targetUserID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedRemoveConnection, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessRemoveConnection)
+}
+
+func (h *connectionHandler) GetConnections(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.ConnectionService.GetConnections(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetConnections, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetConnections)
+}
+
+func (h *connectionHandler) GetIncomingRequests(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.ConnectionService.GetIncomingRequests(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetConnections, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetConnections)
+}
+
+func (h *connectionHandler) GetOutgoingRequests(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.ConnectionService.GetOutgoingRequests(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetConnections, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetConnections)
+}
+
+func (h *connectionHandler) GetConnectionStatus(c *fiber.Ctx) error {
+	targetUserID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.ConnectionService.GetConnectionStatus(c.Context(), userID, targetUserID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetConnectionState, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetConnectionState)
+}
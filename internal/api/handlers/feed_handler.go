@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/feed"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	FeedHandler interface {
+		GetFeed(c *fiber.Ctx) error
+		GetHashtagFeed(c *fiber.Ctx) error
+	}
+
+	feedHandler struct {
+		FeedService feed.FeedService
+		Validator   *validator.Validate
+	}
+)
+
+func NewFeedHandler(feedService feed.FeedService, validator *validator.Validate) FeedHandler {
+	return &feedHandler{
+		FeedService: feedService,
+		Validator:   validator,
+	}
+}
+
+func (h *feedHandler) GetFeed(c *fiber.Ctx) error {
+	viewerID, _ := c.Locals("user_id").(string)
+
+	posts, meta, err := h.FeedService.GetFeed(c.Context(), viewerID, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetFeed, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, posts, meta, fiber.StatusOK, domain.MessageSuccessGetFeed)
+}
+
+func (h *feedHandler) GetHashtagFeed(c *fiber.Ctx) error {
+	hashtag := c.Params("name")
+	viewerID, _ := c.Locals("user_id").(string)
+
+	posts, meta, err := h.FeedService.GetHashtagFeed(c.Context(), hashtag, viewerID, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetHashtagFeed, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, posts, meta, fiber.StatusOK, domain.MessageSuccessGetHashtagFeed)
+}
@@ -20,6 +20,9 @@ type (
 		ApplyJob(c *fiber.Ctx) error
 		GetApplicants(c *fiber.Ctx) error
 		ChangeApplicationStatus(c *fiber.Ctx) error
+		SaveJob(c *fiber.Ctx) error
+		UnsaveJob(c *fiber.Ctx) error
+		GetSavedJobs(c *fiber.Ctx) error
 	}
 	jobHandler struct {
 		JobService job.JobService
@@ -36,8 +39,9 @@ func NewJobHandler(jobService job.JobService, validator *validator.Validate) Job
 
 func (h *jobHandler) GetJobDetail(c *fiber.Ctx) error {
 	id := c.Params("id")
+	viewerID, _ := c.Locals("user_id").(string)
 
-	res, err := h.JobService.GetJobDetail(c.Context(), id)
+	res, err := h.JobService.GetJobDetail(c.Context(), id, viewerID)
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetJobDetail, err)
@@ -48,7 +52,8 @@ func (h *jobHandler) GetJobDetail(c *fiber.Ctx) error {
 
 func (h *jobHandler) SearchJob(c *fiber.Ctx) error {
 
-	var title = c.Query("title")
+	// title is what the search parameter used to be called.
+	var query = c.Query("q", c.Query("title"))
 	var jobType = c.Query("job_type")
 	var locationType = c.Query("location_type")
 	var minSalaryStr = c.Query("min_salary")
@@ -71,7 +76,7 @@ func (h *jobHandler) SearchJob(c *fiber.Ctx) error {
 	var datePosted = c.Query("date_posted")
 
 	var jobSearchRequest = domain.JobSearchRequest{
-		Title:           title,
+		Query:           query,
 		JobType:         jobType,
 		LocationType:    locationType,
 		ExperienceLevel: experienceLevel,
@@ -81,13 +86,15 @@ func (h *jobHandler) SearchJob(c *fiber.Ctx) error {
 		DatePosted:      datePosted,
 	}
 
-	res, err := h.JobService.SearchJob(c.Context(), jobSearchRequest)
+	viewerID, _ := c.Locals("user_id").(string)
+
+	res, meta, err := h.JobService.SearchJob(c.Context(), jobSearchRequest, viewerID, getPagination(c))
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetJobs, err)
 	}
 
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessSearchJobs)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessSearchJobs)
 }
 
 func (h *jobHandler) ApplyJob(c *fiber.Ctx) error {
@@ -119,13 +126,13 @@ func (h *jobHandler) GetApplicants(c *fiber.Ctx) error {
 
 	userID := c.Locals("user_id").(string)
 
-	res, err := h.JobService.GetApplicants(c.Context(), jobID, userID)
+	res, meta, err := h.JobService.GetApplicants(c.Context(), jobID, userID, getPagination(c))
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetApplicants, err)
 	}
 
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetApplicants)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessGetApplicants)
 }
 
 func (h *jobHandler) ChangeApplicationStatus(c *fiber.Ctx) error {
@@ -149,3 +156,37 @@ func (h *jobHandler) ChangeApplicationStatus(c *fiber.Ctx) error {
 
 	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessChangeApplicationStatus)
 }
+
+func (h *jobHandler) SaveJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.JobService.SaveJob(c.Context(), jobID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSaveJob, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusCreated, domain.MessageSuccessSaveJob)
+}
+
+func (h *jobHandler) UnsaveJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.JobService.UnsaveJob(c.Context(), jobID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnsaveJob, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnsaveJob)
+}
+
+func (h *jobHandler) GetSavedJobs(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	res, meta, err := h.JobService.GetSavedJobs(c.Context(), userID, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetSavedJobs, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessGetSavedJobs)
+}
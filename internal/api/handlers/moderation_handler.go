@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/moderation"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	ModerationHandler interface {
+		ReportContent(c *fiber.Ctx) error
+		GetReports(c *fiber.Ctx) error
+		Moderate(c *fiber.Ctx) error
+		GetActions(c *fiber.Ctx) error
+	}
+
+	moderationHandler struct {
+		ModerationService moderation.ModerationService
+		Validator         *validator.Validate
+	}
+)
+
+func NewModerationHandler(moderationService moderation.ModerationService, validator *validator.Validate) ModerationHandler {
+	return &moderationHandler{
+		ModerationService: moderationService,
+		Validator:         validator,
+	}
+}
+
+func (h *moderationHandler) ReportContent(c *fiber.Ctx) error {
+	req := new(domain.ReportRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedReportContent, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ModerationService.ReportContent(c.Context(), *req, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedReportContent, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessReportContent)
+}
+
+func (h *moderationHandler) GetReports(c *fiber.Ctx) error {
+	reports, meta, err := h.ModerationService.GetReports(c.Context(), c.Query("status"), c.Query("target_type"), getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetReports, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, reports, meta, fiber.StatusOK, domain.MessageSuccessGetReports)
+}
+
+func (h *moderationHandler) Moderate(c *fiber.Ctx) error {
+	req := new(domain.ModerateRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedModerate, err)
+	}
+
+	adminID := c.Locals("user_id").(string)
+
+	if err := h.ModerationService.Moderate(c.Context(), *req, adminID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedModerate, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessModerate)
+}
+
+func (h *moderationHandler) GetActions(c *fiber.Ctx) error {
+	actions, meta, err := h.ModerationService.GetActions(c.Context(), c.Query("target_id"), getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetActions, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, actions, meta, fiber.StatusOK, domain.MessageSuccessGetActions)
+}
@@ -35,13 +35,13 @@ func (h *notificationHandler) GetNotifications(c *fiber.Ctx) error {
 
 	fmt.Printf("userID: %s\n", userID)
 
-	res, err := h.NotificationService.GetNotification(c.Context(), userID)
+	res, meta, err := h.NotificationService.GetNotification(c.Context(), userID, getPagination(c))
 
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetNotification, err)
 	}
 
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetNotification)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessGetNotification)
 }
 
 func (h *notificationHandler) ReadNotification(c *fiber.Ctx) error {
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"Go-Starter-Template/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func getPagination(c *fiber.Ctx) domain.PaginationRequest {
+	limit := c.QueryInt("limit", domain.PaginationDefaultLimit)
+
+	if limit <= 0 {
+		limit = domain.PaginationDefaultLimit
+	}
+
+	if limit > domain.PaginationMaxLimit {
+		limit = domain.PaginationMaxLimit
+	}
+
+	return domain.PaginationRequest{
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/poll"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	PollHandler interface {
+		GetPoll(c *fiber.Ctx) error
+		VotePoll(c *fiber.Ctx) error
+	}
+
+	pollHandler struct {
+		PollService poll.PollService
+		Validator   *validator.Validate
+	}
+)
+
+func NewPollHandler(pollService poll.PollService, validator *validator.Validate) PollHandler {
+	return &pollHandler{
+		PollService: pollService,
+		Validator:   validator,
+	}
+}
+
+func (h *pollHandler) GetPoll(c *fiber.Ctx) error {
+	pollID := c.Params("id")
+	viewerID, _ := c.Locals("user_id").(string)
+
+	res, err := h.PollService.GetPoll(c.Context(), pollID, viewerID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetPoll, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetPoll)
+}
+
+func (h *pollHandler) VotePoll(c *fiber.Ctx) error {
+	req := new(domain.VotePollRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedVotePoll, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	res, err := h.PollService.VotePoll(c.Context(), *req, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedVotePoll, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessVotePoll)
+}
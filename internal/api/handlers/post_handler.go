@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Go-Starter-Template/pkg/post"
+	"mime/multipart"
 
 	"github.com/go-playground/validator/v10"
 
@@ -17,7 +18,12 @@ type (
 		CreatePost(c *fiber.Ctx) error
 		UpdatePost(c *fiber.Ctx) error
 		DeletePost(c *fiber.Ctx) error
-		GetFeed(c *fiber.Ctx) error
+		PublishPost(c *fiber.Ctx) error
+		SchedulePost(c *fiber.Ctx) error
+		CancelScheduledPost(c *fiber.Ctx) error
+		GetDrafts(c *fiber.Ctx) error
+		GetScheduledPosts(c *fiber.Ctx) error
+		GetPostRevisions(c *fiber.Ctx) error
 	}
 	postHandler struct {
 		PostService post.PostService
@@ -44,7 +50,7 @@ func (h *postHandler) CreatePost(c *fiber.Ctx) error {
 	}
 
 	userID := c.Locals("user_id").(string)
-	req.Asset, _ = c.FormFile("asset")
+	req.Attachments = getAttachments(c)
 
 	err := h.PostService.CreatePost(c.Context(), req, userID)
 
@@ -67,7 +73,7 @@ func (h *postHandler) UpdatePost(c *fiber.Ctx) error {
 	}
 
 	userID := c.Locals("user_id").(string)
-	req.Asset, _ = c.FormFile("asset")
+	req.Attachments = getAttachments(c)
 
 	err := h.PostService.UpdatePost(c.Context(), req, userID)
 
@@ -91,12 +97,100 @@ func (h *postHandler) DeletePost(c *fiber.Ctx) error {
 	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessDeletePost)
 }
 
-func (h *postHandler) GetFeed(c *fiber.Ctx) error {
-	posts, err := h.PostService.GetFeed(c.Context())
+func (h *postHandler) PublishPost(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	err := h.PostService.PublishPost(c.Context(), postID, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedPublishPost, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessPublishPost)
+}
+
+func (h *postHandler) SchedulePost(c *fiber.Ctx) error {
+	var req domain.SchedulePostRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSchedulePost, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSchedulePost, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	err := h.PostService.SchedulePost(c.Context(), req, userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSchedulePost, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessSchedulePost)
+}
+
+func (h *postHandler) CancelScheduledPost(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	err := h.PostService.CancelScheduledPost(c.Context(), postID, userID)
 
 	if err != nil {
-		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetFeed, err)
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedCancelSchedule, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessCancelSchedule)
+}
+
+func (h *postHandler) GetDrafts(c *fiber.Ctx) error {
+	return h.getUnpublishedPosts(c, domain.PostStatusDraft)
+}
+
+func (h *postHandler) GetScheduledPosts(c *fiber.Ctx) error {
+	return h.getUnpublishedPosts(c, domain.PostStatusScheduled)
+}
+
+func (h *postHandler) getUnpublishedPosts(c *fiber.Ctx, status string) error {
+	userID := c.Locals("user_id").(string)
+
+	posts, meta, err := h.PostService.GetUnpublishedPosts(c.Context(), userID, status, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetUnpublishedPost, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, posts, meta, fiber.StatusOK, domain.MessageSuccessGetUnpublishedPost)
+}
+
+func (h *postHandler) GetPostRevisions(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	viewerID, _ := c.Locals("user_id").(string)
+	viewerRole, _ := c.Locals("role").(string)
+
+	revisions, meta, err := h.PostService.GetPostRevisions(c.Context(), postID, viewerID, viewerRole, getPagination(c))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetPostRevisions, err)
+	}
+
+	return presenters.SuccessResponseWithMeta(c, revisions, meta, fiber.StatusOK, domain.MessageSuccessGetPostRevisions)
+}
+
+// getAttachments collects the uploaded "attachments" files in the order they
+// were sent, falling back to the single "asset" file older clients send.
+func getAttachments(c *fiber.Ctx) []*multipart.FileHeader {
+	form, err := c.MultipartForm()
+
+	if err != nil {
+		return nil
+	}
+
+	if files := form.File["attachments"]; len(files) > 0 {
+		return files
 	}
 
-	return presenters.SuccessResponse(c, posts, fiber.StatusOK, domain.MessageSuccessGetFeed)
+	return form.File["asset"]
 }
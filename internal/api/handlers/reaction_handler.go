@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/reaction"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	ReactionHandler interface {
+		ReactPost(c *fiber.Ctx) error
+		UnreactPost(c *fiber.Ctx) error
+	}
+
+	reactionHandler struct {
+		ReactionService reaction.ReactionService
+		Validator       *validator.Validate
+	}
+)
+
+func NewReactionHandler(reactionService reaction.ReactionService, validator *validator.Validate) ReactionHandler {
+	return &reactionHandler{
+		ReactionService: reactionService,
+		Validator:       validator,
+	}
+}
+
+func (h *reactionHandler) ReactPost(c *fiber.Ctx) error {
+	req := new(domain.ReactPostRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedReactPost, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ReactionService.ReactPost(c.Context(), *req, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedReactPost, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessReactPost)
+}
+
+func (h *reactionHandler) UnreactPost(c *fiber.Ctx) error {
+	postID := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ReactionService.UnreactPost(c.Context(), postID, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnreactPost, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnreactPost)
+}
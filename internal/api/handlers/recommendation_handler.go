@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/recommendation"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	RecommendationHandler interface {
+		GetPeopleYouMayKnow(c *fiber.Ctx) error
+		GetJobsForYou(c *fiber.Ctx) error
+	}
+
+	recommendationHandler struct {
+		RecommendationService recommendation.RecommendationService
+		Validator             *validator.Validate
+	}
+)
+
+func NewRecommendationHandler(recommendationService recommendation.RecommendationService, validator *validator.Validate) RecommendationHandler {
+	return &recommendationHandler{
+		RecommendationService: recommendationService,
+		Validator:             validator,
+	}
+}
+
+func (h *recommendationHandler) GetPeopleYouMayKnow(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	limit := c.QueryInt("limit", domain.RecommendationDefaultLimit)
+
+	res, err := h.RecommendationService.GetPeopleYouMayKnow(c.Context(), userID, limit)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetRecommendations, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetRecommendations)
+}
+
+func (h *recommendationHandler) GetJobsForYou(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	req := domain.JobsForYouRequest{
+		Location:     c.Query("location"),
+		LocationType: c.Query("location_type"),
+		Limit:        c.QueryInt("limit", domain.RecommendationDefaultLimit),
+	}
+
+	res, err := h.RecommendationService.GetJobsForYou(c.Context(), userID, req)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetJobsForYou, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetJobsForYou)
+}
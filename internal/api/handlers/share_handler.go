@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/share"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	ShareHandler interface {
+		SharePost(c *fiber.Ctx) error
+	}
+
+	shareHandler struct {
+		ShareService share.ShareService
+		Validator    *validator.Validate
+	}
+)
+
+func NewShareHandler(shareService share.ShareService, validator *validator.Validate) ShareHandler {
+	return &shareHandler{
+		ShareService: shareService,
+		Validator:    validator,
+	}
+}
+
+func (h *shareHandler) SharePost(c *fiber.Ctx) error {
+	req := new(domain.SharePostRequest)
+
+	if err := c.BodyParser(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedBodyRequest, err)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSharePost, err)
+	}
+
+	userID := c.Locals("user_id").(string)
+
+	if err := h.ShareService.SharePost(c.Context(), *req, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSharePost, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessSharePost)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/tag"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	TagHandler interface {
+		FollowHashtag(c *fiber.Ctx) error
+		UnfollowHashtag(c *fiber.Ctx) error
+		GetFollowedHashtags(c *fiber.Ctx) error
+	}
+
+	tagHandler struct {
+		TagService tag.TagService
+		Validator  *validator.Validate
+	}
+)
+
+func NewTagHandler(tagService tag.TagService, validator *validator.Validate) TagHandler {
+	return &tagHandler{
+		TagService: tagService,
+		Validator:  validator,
+	}
+}
+
+func (h *tagHandler) FollowHashtag(c *fiber.Ctx) error {
+	name := c.Params("name")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.TagService.FollowHashtag(c.Context(), name, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedFollowHashtag, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessFollowHashtag)
+}
+
+func (h *tagHandler) UnfollowHashtag(c *fiber.Ctx) error {
+	name := c.Params("name")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.TagService.UnfollowHashtag(c.Context(), name, userID); err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedUnfollowHashtag, err)
+	}
+
+	return presenters.SuccessResponse(c, nil, fiber.StatusOK, domain.MessageSuccessUnfollowHashtag)
+}
+
+func (h *tagHandler) GetFollowedHashtags(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	hashtags, err := h.TagService.GetFollowedHashtags(c.Context(), userID)
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetFollowedTags, err)
+	}
+
+	return presenters.SuccessResponse(c, hashtags, fiber.StatusOK, domain.MessageSuccessGetFollowedTags)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"Go-Starter-Template/pkg/trending"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gofiber/fiber/v2"
+
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/api/presenters"
+)
+
+type (
+	TrendingHandler interface {
+		GetTrending(c *fiber.Ctx) error
+	}
+
+	trendingHandler struct {
+		TrendingService trending.TrendingService
+		Validator       *validator.Validate
+	}
+)
+
+func NewTrendingHandler(trendingService trending.TrendingService, validator *validator.Validate) TrendingHandler {
+	return &trendingHandler{
+		TrendingService: trendingService,
+		Validator:       validator,
+	}
+}
+
+func (h *trendingHandler) GetTrending(c *fiber.Ctx) error {
+	res, err := h.TrendingService.GetTrending(c.Context(), c.Query("window"))
+
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetTrending, err)
+	}
+
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetTrending)
+}
@@ -25,6 +25,7 @@ type (
 		DeleteSkill(c *fiber.Ctx) error
 		SearchUser(c *fiber.Ctx) error
 		GetSkills(c *fiber.Ctx) error
+		GetProfileViews(c *fiber.Ctx) error
 	}
 	userHandler struct {
 		UserService user.UserService
@@ -76,7 +77,8 @@ func (h *userHandler) Login(c *fiber.Ctx) error {
 
 func (h *userHandler) GetProfile(c *fiber.Ctx) error {
 	slug := c.Params("slug")
-	res, err := h.UserService.GetProfile(c.Context(), slug)
+	viewerID, _ := c.Locals("user_id").(string)
+	res, err := h.UserService.GetProfile(c.Context(), slug, viewerID)
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetProfile, err)
 	}
@@ -228,11 +230,13 @@ func (h *userHandler) SearchUser(c *fiber.Ctx) error {
 		Keyword: c.Query("keyword"),
 	}
 
-	res, err := h.UserService.SearchUser(c.Context(), query)
+	viewerID, _ := c.Locals("user_id").(string)
+
+	res, meta, err := h.UserService.SearchUser(c.Context(), query, viewerID, getPagination(c))
 	if err != nil {
 		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedSearchUser, err)
 	}
-	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessSearchUser)
+	return presenters.SuccessResponseWithMeta(c, res, meta, fiber.StatusOK, domain.MessageSuccessSearchUser)
 }
 
 func (h *userHandler) GetSkills(c *fiber.Ctx) error {
@@ -242,3 +246,14 @@ func (h *userHandler) GetSkills(c *fiber.Ctx) error {
 	}
 	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetSkills)
 }
+
+func (h *userHandler) GetProfileViews(c *fiber.Ctx) error {
+	userid := c.Locals("user_id").(string)
+	days := c.QueryInt("days", domain.ProfileViewsDefaultDays)
+
+	res, err := h.UserService.GetProfileViews(c.Context(), userid, days)
+	if err != nil {
+		return presenters.ErrorResponse(c, fiber.StatusBadRequest, domain.MessageFailedGetProfileViews, err)
+	}
+	return presenters.SuccessResponse(c, res, fiber.StatusOK, domain.MessageSuccessGetProfileViews)
+}
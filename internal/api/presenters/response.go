@@ -7,6 +7,7 @@ type Response struct {
 	Message string      `json:"message"`
 	Error   interface{} `json:"error,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
 }
 
 func SuccessResponse(ctx *fiber.Ctx, data interface{}, statusCode int, message string) error {
@@ -19,6 +20,17 @@ func SuccessResponse(ctx *fiber.Ctx, data interface{}, statusCode int, message s
 	return ctx.Status(statusCode).JSON(resp)
 }
 
+func SuccessResponseWithMeta(ctx *fiber.Ctx, data interface{}, meta interface{}, statusCode int, message string) error {
+	resp := Response{
+		Success: true,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	}
+
+	return ctx.Status(statusCode).JSON(resp)
+}
+
 func ErrorResponse(ctx *fiber.Ctx, statusCode int, message string, err error) error {
 	resp := Response{
 		Success: false,
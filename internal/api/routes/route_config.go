@@ -9,17 +9,30 @@ import (
 )
 
 type Config struct {
-	App                 *fiber.App
-	UserHandler         handlers.UserHandler
-	CompanyHandler      handlers.CompanyHandler
-	ChatServerHandler   handlers.ChatServerHandler
-	ChatHandler         handlers.ChatHandler
-	JobHandler          handlers.JobHandler
-	NotificationHandler handlers.NotificationHandler
-	MidtransHandler     handlers.MidtransHandler
-	Middleware          middleware.Middleware
-	JwtService          jwtService.JWTService
-	PostHandler         handlers.PostHandler
+	App                   *fiber.App
+	UserHandler           handlers.UserHandler
+	CompanyHandler        handlers.CompanyHandler
+	ChatServerHandler     *handlers.ChatServerHandler
+	ChatHandler           handlers.ChatHandler
+	JobHandler            handlers.JobHandler
+	NotificationHandler   handlers.NotificationHandler
+	MidtransHandler       handlers.MidtransHandler
+	Middleware            middleware.Middleware
+	JwtService            jwtService.JWTService
+	PostHandler           handlers.PostHandler
+	ConnectionHandler     handlers.ConnectionHandler
+	RecommendationHandler handlers.RecommendationHandler
+	BlockHandler          handlers.BlockHandler
+	FeedHandler           handlers.FeedHandler
+	ReactionHandler       handlers.ReactionHandler
+	CommentHandler        handlers.CommentHandler
+	ShareHandler          handlers.ShareHandler
+	TagHandler            handlers.TagHandler
+	ModerationHandler     handlers.ModerationHandler
+	TrendingHandler       handlers.TrendingHandler
+	PollHandler           handlers.PollHandler
+	AnalyticsHandler      handlers.AnalyticsHandler
+	AlertHandler          handlers.AlertHandler
 }
 
 func (c *Config) Setup() {
@@ -30,6 +43,8 @@ func (c *Config) Setup() {
 	c.Chat()
 	c.Post()
 	c.Notification()
+	c.Connection()
+	c.Moderation()
 	c.GuestRoute()
 	c.AuthRoute()
 }
@@ -37,10 +52,18 @@ func (c *Config) Setup() {
 func (c *Config) User() {
 	user := c.App.Group("/api/user")
 	{
-		user.Get("/search", c.UserHandler.SearchUser)
+		user.Get("/search", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.UserHandler.SearchUser)
 		user.Post("/register", c.UserHandler.RegisterUser)
 		user.Post("/login", c.UserHandler.Login)
-		user.Get("/profile/:slug", c.UserHandler.GetProfile)
+		user.Get("/profile/:slug", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.UserHandler.GetProfile)
+		user.Get("/profile-views", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.UserHandler.GetProfileViews)
+		user.Get("/people-you-may-know", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.RecommendationHandler.GetPeopleYouMayKnow)
+		user.Get("/blocked", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.GetBlockedUsers)
+		user.Get("/muted", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.GetMutedUsers)
+		user.Post("/block/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.BlockUser)
+		user.Delete("/unblock/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.UnblockUser)
+		user.Post("/mute/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.MuteUser)
+		user.Delete("/unmute/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.BlockHandler.UnmuteUser)
 		user.Post("/update-profile", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.UserHandler.UpdateProfile)
 
 		education := user.Group("/education")
@@ -79,22 +102,40 @@ func (c *Config) Company() {
 	{
 		company.Post("/login", c.CompanyHandler.LoginCompany)
 		company.Post("/register", c.CompanyHandler.RegisterCompany)
-		company.Get("/profile/:slug", c.CompanyHandler.GetProfile)
+		company.Get("/profile/:slug", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.CompanyHandler.GetProfile)
 		company.Get("/list", c.CompanyHandler.GetListCompany)
+		company.Get("/following", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.CompanyHandler.GetFollowedCompanies)
+		company.Post("/follow/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.CompanyHandler.FollowCompany)
+		company.Delete("/unfollow/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.CompanyHandler.UnfollowCompany)
 		company.Patch("/update-profile", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.UpdateProfile)
 		company.Post("/add-job", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.AddJob)
 		company.Patch("/update-job", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.UpdateJob)
+		company.Post("/open-job/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.OpenJob)
+		company.Post("/pause-job/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.PauseJob)
+		company.Post("/close-job/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.CompanyHandler.CloseJob)
 	}
 }
 
 func (c *Config) Job() {
 	job := c.App.Group("/api/job")
 	{
-		job.Get("/detail/:id", c.JobHandler.GetJobDetail)
-		job.Get("/search", c.JobHandler.SearchJob)
+		job.Get("/detail/:id", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.JobHandler.GetJobDetail)
+		job.Get("/search", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.JobHandler.SearchJob)
+		job.Get("/for-you", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.RecommendationHandler.GetJobsForYou)
 		job.Get("/applicants/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.JobHandler.GetApplicants)
 		job.Post("/apply", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.JobHandler.ApplyJob)
 		job.Post("/update-application", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("company"), c.JobHandler.ChangeApplicationStatus)
+		job.Get("/saved", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.JobHandler.GetSavedJobs)
+		job.Post("/save/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.JobHandler.SaveJob)
+		job.Delete("/unsave/:id", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.JobHandler.UnsaveJob)
+
+		alerts := job.Group("/alerts", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"))
+		{
+			alerts.Get("/", c.AlertHandler.GetAlerts)
+			alerts.Post("/", c.AlertHandler.CreateAlert)
+			alerts.Patch("/:id", c.AlertHandler.UpdateAlert)
+			alerts.Delete("/:id", c.AlertHandler.DeleteAlert)
+		}
 	}
 }
 
@@ -122,10 +163,78 @@ func (c *Config) Notification() {
 func (c *Config) Post() {
 	post := c.App.Group("/api/post")
 	{
-		post.Get("/feed", c.PostHandler.GetFeed)
+		post.Get("/feed", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.FeedHandler.GetFeed)
+		post.Get("/trending", c.TrendingHandler.GetTrending)
 		post.Post("/create", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.CreatePost)
 		post.Patch("/update", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.UpdatePost)
 		post.Delete("/delete/:id", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.DeletePost)
+		post.Get("/revisions/:id", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.PostHandler.GetPostRevisions)
+		post.Get("/drafts", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.GetDrafts)
+		post.Get("/scheduled", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.GetScheduledPosts)
+		post.Post("/publish/:id", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.PublishPost)
+		post.Post("/schedule", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.SchedulePost)
+		post.Post("/cancel-schedule/:id", c.Middleware.AuthMiddleware(c.JwtService), c.PostHandler.CancelScheduledPost)
+		post.Post("/react", c.Middleware.AuthMiddleware(c.JwtService), c.ReactionHandler.ReactPost)
+		post.Delete("/unreact/:id", c.Middleware.AuthMiddleware(c.JwtService), c.ReactionHandler.UnreactPost)
+		post.Post("/share", c.Middleware.AuthMiddleware(c.JwtService), c.ShareHandler.SharePost)
+
+		hashtag := post.Group("/hashtag")
+		{
+			hashtag.Get("/feed/:name", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.FeedHandler.GetHashtagFeed)
+			hashtag.Get("/followed", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.TagHandler.GetFollowedHashtags)
+			hashtag.Post("/follow/:name", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.TagHandler.FollowHashtag)
+			hashtag.Delete("/unfollow/:name", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"), c.TagHandler.UnfollowHashtag)
+		}
+
+		comment := post.Group("/comment")
+		{
+			comment.Get("/list/:id", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.CommentHandler.GetComments)
+			comment.Get("/replies/:id", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.CommentHandler.GetReplies)
+			comment.Post("/create", c.Middleware.AuthMiddleware(c.JwtService), c.CommentHandler.CreateComment)
+			comment.Patch("/update", c.Middleware.AuthMiddleware(c.JwtService), c.CommentHandler.UpdateComment)
+			comment.Delete("/delete/:id", c.Middleware.AuthMiddleware(c.JwtService), c.CommentHandler.DeleteComment)
+		}
+
+		analytics := post.Group("/analytics", c.Middleware.AuthMiddleware(c.JwtService))
+		{
+			analytics.Get("/:id", c.AnalyticsHandler.GetPostAnalytics)
+			analytics.Post("/profile-click/:id", c.AnalyticsHandler.RecordProfileClick)
+		}
+
+		poll := post.Group("/poll")
+		{
+			poll.Get("/:id", c.Middleware.OptionalAuthMiddleware(c.JwtService), c.PollHandler.GetPoll)
+			poll.Post("/vote", c.Middleware.AuthMiddleware(c.JwtService), c.PollHandler.VotePoll)
+		}
+	}
+}
+
+func (c *Config) Connection() {
+	connection := c.App.Group("/api/connection", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("user"))
+	{
+		connection.Get("/list", c.ConnectionHandler.GetConnections)
+		connection.Get("/incoming", c.ConnectionHandler.GetIncomingRequests)
+		connection.Get("/outgoing", c.ConnectionHandler.GetOutgoingRequests)
+		connection.Get("/status/:id", c.ConnectionHandler.GetConnectionStatus)
+		connection.Post("/send/:id", c.ConnectionHandler.SendRequest)
+		connection.Post("/accept/:id", c.ConnectionHandler.AcceptRequest)
+		connection.Post("/reject/:id", c.ConnectionHandler.RejectRequest)
+		connection.Post("/withdraw/:id", c.ConnectionHandler.WithdrawRequest)
+		connection.Delete("/remove/:id", c.ConnectionHandler.RemoveConnection)
+	}
+}
+
+func (c *Config) Moderation() {
+	report := c.App.Group("/api/report")
+	{
+		report.Post("/create", c.Middleware.AuthMiddleware(c.JwtService), c.ModerationHandler.ReportContent)
+	}
+
+	admin := c.App.Group("/api/admin", c.Middleware.AuthMiddleware(c.JwtService), c.Middleware.OnlyAllow("admin"))
+	{
+		admin.Get("/reports", c.ModerationHandler.GetReports)
+		admin.Get("/actions", c.ModerationHandler.GetActions)
+		admin.Post("/moderate", c.ModerationHandler.Moderate)
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/internal/api/presenters"
 	jwtService "Go-Starter-Template/pkg/jwt"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"strings"
 )
@@ -26,6 +27,41 @@ func (m *middleware) AuthMiddleware(jwtService jwtService.JWTService) fiber.Hand
 		if userId == "" {
 			return presenters.ErrorResponse(c, fiber.StatusUnauthorized, domain.MessageFailedProcessRequest, err)
 		}
+
+		suspended, err := m.isSuspended(c.Context(), userId)
+		if errors.Is(err, domain.ErrCheckSuspension) {
+			return presenters.ErrorResponse(c, fiber.StatusInternalServerError, domain.MessageFailedProcessRequest, err)
+		}
+		if err != nil {
+			return presenters.ErrorResponse(c, fiber.StatusUnauthorized, domain.MessageFailedProcessRequest, err)
+		}
+		if suspended {
+			return presenters.ErrorResponse(c, fiber.StatusForbidden, domain.MessageFailedProcessRequest, domain.ErrAccountSuspended)
+		}
+		c.Locals("user_id", userId)
+		c.Locals("role", userRole)
+		c.Locals("token", authHeader)
+		return c.Next()
+	}
+}
+
+func (m *middleware) OptionalAuthMiddleware(jwtService jwtService.JWTService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" || !strings.Contains(authHeader, "Bearer") {
+			return c.Next()
+		}
+		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
+
+		userId, userRole, err := jwtService.GetUserIDByToken(authHeader)
+		if err != nil || userId == "" {
+			return c.Next()
+		}
+		// Suspended accounts, or ones whose status cannot be checked, browse
+		// as anonymous visitors.
+		if suspended, err := m.isSuspended(c.Context(), userId); err != nil || suspended {
+			return c.Next()
+		}
 		c.Locals("user_id", userId)
 		c.Locals("role", userRole)
 		c.Locals("token", authHeader)
@@ -2,19 +2,25 @@ package middleware
 
 import (
 	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/user"
+	"sync"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 type (
 	Middleware interface {
 		AuthMiddleware(jwtService jwtService.JWTService) fiber.Handler
+		OptionalAuthMiddleware(jwtService jwtService.JWTService) fiber.Handler
 		CORSMiddleware() fiber.Handler
 		OnlyAllow(allow string) fiber.Handler
 	}
 	middleware struct {
+		userRepository user.UserRepository
+		suspensions    sync.Map
 	}
 )
 
-func NewMiddleware() Middleware {
-	return &middleware{}
+func NewMiddleware(userRepository user.UserRepository) Middleware {
+	return &middleware{userRepository: userRepository}
 }
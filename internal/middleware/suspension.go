@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"Go-Starter-Template/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// suspension is a cached lookup of when a user's suspension ends.
+type suspension struct {
+	until     *time.Time
+	checkedAt time.Time
+}
+
+// isSuspended reports whether the user is currently suspended. Lookups are
+// kept for domain.SuspensionCheckTTL so not every request reads the user.
+func (m *middleware) isSuspended(ctx context.Context, userID string) (bool, error) {
+	now := time.Now()
+
+	cached, ok := m.suspensions.Load(userID)
+
+	if !ok || now.Sub(cached.(suspension).checkedAt) > domain.SuspensionCheckTTL {
+		parsedUserID, err := uuid.Parse(userID)
+
+		if err != nil {
+			return false, domain.ErrParseUUID
+		}
+
+		user, err := m.userRepository.GetUserByID(ctx, parsedUserID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, domain.ErrUserNotFound
+		}
+
+		if err != nil {
+			return false, domain.ErrCheckSuspension
+		}
+
+		cached = suspension{until: user.SuspendedUntil, checkedAt: now}
+		m.suspensions.Store(userID, cached)
+	}
+
+	until := cached.(suspension).until
+
+	return until != nil && until.After(now), nil
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const cursorSeparator = "\x1f"
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor packs the sort key of the last row of a page into an opaque
+// token the client sends back to get the next page.
+func EncodeCursor(values ...string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(values, cursorSeparator)))
+}
+
+func DecodeCursor(cursor string) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return strings.Split(string(raw), cursorSeparator), nil
+}
+
+// EncodeKeyCursor is the cursor of a list ordered by a single column with the
+// row ID as a tie breaker.
+func EncodeKeyCursor(key string, id uuid.UUID) string {
+	return EncodeCursor(key, id.String())
+}
+
+func DecodeKeyCursor(cursor string) (string, uuid.UUID, error) {
+	values, err := DecodeCursor(cursor)
+
+	if err != nil || len(values) != 2 {
+		return "", uuid.Nil, errInvalidCursor
+	}
+
+	id, err := uuid.Parse(values[1])
+
+	if err != nil {
+		return "", uuid.Nil, errInvalidCursor
+	}
+
+	return values[0], id, nil
+}
+
+func EncodeTimeCursor(t time.Time, id uuid.UUID) string {
+	return EncodeKeyCursor(t.Format(time.RFC3339Nano), id)
+}
+
+func DecodeTimeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	key, id, err := DecodeKeyCursor(cursor)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, key)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, errInvalidCursor
+	}
+
+	return t, id, nil
+}
+
+// TrimPage cuts a page that was fetched with limit+1 rows back to limit and
+// reports whether there are more rows after it.
+func TrimPage[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
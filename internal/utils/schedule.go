@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunEvery calls job every interval until ctx is done. Failures are logged
+// and the job is tried again on the next tick.
+func RunEvery(ctx context.Context, interval time.Duration, name string, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Printf("%s: %v", name, err)
+			}
+		}
+	}
+}
@@ -14,3 +14,23 @@ func ConvertStringToTime(date string) time.Time {
 func ConvertTimeToString(date time.Time) string {
 	return date.Format("01-02-2006")
 }
+
+func ConvertOptionalTimeToString(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return ConvertTimeToString(*date)
+}
+
+// ConvertOptionalTimestampToString keeps the time of day, for values such as
+// deadlines that clients pass back in RFC 3339.
+func ConvertOptionalTimestampToString(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(time.RFC3339)
+}
+
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,196 @@
+package alert
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/job"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	AlertRepository interface {
+		CountAlerts(ctx context.Context, userID uuid.UUID) (int64, error)
+		CreateAlert(ctx context.Context, alert entities.JobAlert) error
+		GetAlerts(ctx context.Context, userID uuid.UUID) ([]entities.JobAlert, error)
+		GetAlert(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (entities.JobAlert, error)
+		UpdateAlert(ctx context.Context, alert entities.JobAlert) error
+		DeleteAlert(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (bool, error)
+		GetDueAlerts(ctx context.Context, now time.Time, limit int) ([]entities.JobAlert, error)
+		ClaimAlertRun(ctx context.Context, alert entities.JobAlert, nextRunAt time.Time) (bool, error)
+		GetNewJobs(ctx context.Context, alert entities.JobAlert, limit int) ([]entities.Job, error)
+		DeliverJobs(ctx context.Context, alertID uuid.UUID, deliveries []entities.JobAlertDelivery, notification entities.Notification, lastRunAt time.Time) (bool, error)
+	}
+
+	alertRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAlertRepository(db *gorm.DB) AlertRepository {
+	return &alertRepository{db: db}
+}
+
+func (r *alertRepository) CountAlerts(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+
+	if err := r.db.WithContext(ctx).Model(&entities.JobAlert{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *alertRepository) CreateAlert(ctx context.Context, alert entities.JobAlert) error {
+	if err := r.db.WithContext(ctx).Create(&alert).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *alertRepository) GetAlerts(ctx context.Context, userID uuid.UUID) ([]entities.JobAlert, error) {
+	var alerts []entities.JobAlert
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (r *alertRepository) GetAlert(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (entities.JobAlert, error) {
+	var alert entities.JobAlert
+
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", alertID, userID).First(&alert).Error; err != nil {
+		return entities.JobAlert{}, err
+	}
+
+	return alert, nil
+}
+
+func (r *alertRepository) UpdateAlert(ctx context.Context, alert entities.JobAlert) error {
+	err := r.db.WithContext(ctx).Model(&entities.JobAlert{}).Where("id = ?", alert.ID).
+		Select("name", "query", "job_type", "location_type", "experience_level", "min_salary", "max_salary", "frequency", "next_run_at").
+		Updates(&alert).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *alertRepository) DeleteAlert(ctx context.Context, alertID uuid.UUID, userID uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", alertID, userID).Delete(&entities.JobAlert{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *alertRepository) GetDueAlerts(ctx context.Context, now time.Time, limit int) ([]entities.JobAlert, error) {
+	var alerts []entities.JobAlert
+
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("next_run_at <= ?", now).
+		Order("next_run_at").
+		Limit(limit).
+		Find(&alerts).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// ClaimAlertRun moves the alert on to its next run. It only succeeds for
+// the first caller, so an alert is never run twice for the same period.
+func (r *alertRepository) ClaimAlertRun(ctx context.Context, alert entities.JobAlert, nextRunAt time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&entities.JobAlert{}).
+		Where("id = ? AND next_run_at = ?", alert.ID, alert.NextRunAt).
+		Update("next_run_at", nextRunAt)
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// GetNewJobs lists the listed jobs matching the alert that opened since its
+// last run and were not sent to the user yet, oldest first, so jobs past the
+// limit are picked up by the next run.
+func (r *alertRepository) GetNewJobs(ctx context.Context, alert entities.JobAlert, limit int) ([]entities.Job, error) {
+	var jobs []entities.Job
+
+	filters := domain.JobSearchRequest{
+		Query:           alert.Query,
+		JobType:         alert.JobType,
+		LocationType:    alert.LocationType,
+		ExperienceLevel: alert.ExperienceLevel,
+		MinSalary:       alert.MinSalary,
+		MaxSalary:       alert.MaxSalary,
+	}
+
+	err := r.db.WithContext(ctx).Preload("Company").
+		Scopes(job.Listed, job.MatchingSearch(filters)).
+		Where("jobs.hidden_at IS NULL AND jobs.opened_at >= ?", alert.LastRunAt).
+		Where("NOT EXISTS (SELECT 1 FROM job_alert_deliveries WHERE job_alert_deliveries.job_id = jobs.id AND job_alert_deliveries.user_id = ?)", alert.UserID).
+		Order("jobs.opened_at, jobs.id").
+		Limit(limit).
+		Find(&jobs).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// errAlreadyDelivered rolls back a delivery that raced another one.
+var errAlreadyDelivered = errors.New("job was already delivered")
+
+// DeliverJobs records the jobs as sent to the user, stores the notification
+// about them and moves the alert's last run up to lastRunAt, all in one
+// transaction. It reports false, leaving everything as it was, when one of
+// the jobs reached the user through another alert in the meantime.
+func (r *alertRepository) DeliverJobs(ctx context.Context, alertID uuid.UUID, deliveries []entities.JobAlertDelivery, notification entities.Notification, lastRunAt time.Time) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries)
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected < int64(len(deliveries)) {
+			return errAlreadyDelivered
+		}
+
+		if err := tx.Create(&notification).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.JobAlert{}).
+			Where("id = ? AND last_run_at < ?", alertID, lastRunAt).
+			Update("last_run_at", lastRunAt).Error
+	})
+
+	if errors.Is(err, errAlreadyDelivered) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
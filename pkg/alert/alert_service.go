@@ -0,0 +1,310 @@
+package alert
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/internal/utils/mailing"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AlertService interface {
+		CreateAlert(ctx context.Context, req domain.JobAlertRequest, userID string) (domain.JobAlertResponse, error)
+		GetAlerts(ctx context.Context, userID string) ([]domain.JobAlertResponse, error)
+		UpdateAlert(ctx context.Context, alertID string, req domain.JobAlertRequest, userID string) (domain.JobAlertResponse, error)
+		DeleteAlert(ctx context.Context, alertID string, userID string) error
+		DeliverAlerts(ctx context.Context) error
+	}
+
+	alertService struct {
+		alertRepository        AlertRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewAlertService(alertRepository AlertRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) AlertService {
+	return &alertService{alertRepository: alertRepository, notificationRepository: notificationRepository, jwtService: jwtService}
+}
+
+func toAlertResponse(alert entities.JobAlert) domain.JobAlertResponse {
+	return domain.JobAlertResponse{
+		ID:              alert.ID.String(),
+		Name:            alert.Name,
+		Frequency:       alert.Frequency,
+		Query:           alert.Query,
+		JobType:         alert.JobType,
+		LocationType:    alert.LocationType,
+		ExperienceLevel: alert.ExperienceLevel,
+		MinSalary:       alert.MinSalary,
+		MaxSalary:       alert.MaxSalary,
+		NextRunAt:       alert.NextRunAt.Format(time.RFC3339),
+		CreatedAt:       utils.ConvertTimeToString(alert.CreatedAt),
+	}
+}
+
+// applyRequest copies the saved search onto the alert and schedules its next
+// run one period from now.
+func applyRequest(alert *entities.JobAlert, req domain.JobAlertRequest, now time.Time) {
+	alert.Name = strings.TrimSpace(req.Name)
+	alert.Query = strings.TrimSpace(req.Query)
+	alert.JobType = req.JobType
+	alert.LocationType = req.LocationType
+	alert.ExperienceLevel = req.ExperienceLevel
+	alert.MinSalary = req.MinSalary
+	alert.MaxSalary = req.MaxSalary
+	alert.Frequency = req.Frequency
+	alert.NextRunAt = now.Add(domain.JobAlertPeriods[req.Frequency])
+}
+
+func (s *alertService) CreateAlert(ctx context.Context, req domain.JobAlertRequest, userID string) (domain.JobAlertResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.JobAlertResponse{}, domain.ErrParseUUID
+	}
+
+	count, err := s.alertRepository.CountAlerts(ctx, parsedUserID)
+
+	if err != nil {
+		return domain.JobAlertResponse{}, domain.ErrCreateJobAlert
+	}
+
+	if count >= domain.JobAlertMaxPerUser {
+		return domain.JobAlertResponse{}, domain.ErrJobAlertLimitReached
+	}
+
+	now := time.Now()
+
+	alert := entities.JobAlert{
+		ID:        uuid.New(),
+		UserID:    parsedUserID,
+		LastRunAt: now,
+	}
+	applyRequest(&alert, req, now)
+
+	if err := s.alertRepository.CreateAlert(ctx, alert); err != nil {
+		return domain.JobAlertResponse{}, domain.ErrCreateJobAlert
+	}
+
+	alert.CreatedAt = now
+
+	return toAlertResponse(alert), nil
+}
+
+func (s *alertService) GetAlerts(ctx context.Context, userID string) ([]domain.JobAlertResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	alerts, err := s.alertRepository.GetAlerts(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetJobAlerts
+	}
+
+	responses := make([]domain.JobAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		responses = append(responses, toAlertResponse(alert))
+	}
+
+	return responses, nil
+}
+
+func (s *alertService) UpdateAlert(ctx context.Context, alertID string, req domain.JobAlertRequest, userID string) (domain.JobAlertResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.JobAlertResponse{}, domain.ErrParseUUID
+	}
+
+	parsedAlertID, err := uuid.Parse(alertID)
+
+	if err != nil {
+		return domain.JobAlertResponse{}, domain.ErrParseUUID
+	}
+
+	alert, err := s.alertRepository.GetAlert(ctx, parsedAlertID, parsedUserID)
+
+	if err != nil {
+		return domain.JobAlertResponse{}, domain.ErrJobAlertNotFound
+	}
+
+	applyRequest(&alert, req, time.Now())
+
+	if err := s.alertRepository.UpdateAlert(ctx, alert); err != nil {
+		return domain.JobAlertResponse{}, domain.ErrUpdateJobAlert
+	}
+
+	return toAlertResponse(alert), nil
+}
+
+func (s *alertService) DeleteAlert(ctx context.Context, alertID string, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedAlertID, err := uuid.Parse(alertID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	deleted, err := s.alertRepository.DeleteAlert(ctx, parsedAlertID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrDeleteJobAlert
+	}
+
+	if !deleted {
+		return domain.ErrJobAlertNotFound
+	}
+
+	return nil
+}
+
+// DeliverAlerts runs the alerts that are due. The jobs of an alert are
+// recorded as sent together with the notification about them, so each job
+// reaches a user at most once and is never marked sent without it. The
+// alert's last run only moves past the jobs that were sent, so jobs over the
+// limit, or left behind by an error, go out on a later run. An alert that
+// fails does not hold up the others; the errors are reported once every
+// alert has been run.
+func (s *alertService) DeliverAlerts(ctx context.Context) error {
+	now := time.Now()
+
+	alerts, err := s.alertRepository.GetDueAlerts(ctx, now, domain.JobAlertBatch)
+
+	if err != nil {
+		return err
+	}
+
+	appURL := mailing.LoadMailConfig().AppURL
+
+	var errs []error
+
+	for _, alert := range alerts {
+		if err := s.deliverAlert(ctx, alert, now, appURL); err != nil {
+			errs = append(errs, fmt.Errorf("deliver job alert %s: %w", alert.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *alertService) deliverAlert(ctx context.Context, alert entities.JobAlert, now time.Time, appURL string) error {
+	claimed, err := s.alertRepository.ClaimAlertRun(ctx, alert, now.Add(domain.JobAlertPeriods[alert.Frequency]))
+
+	if err != nil {
+		return err
+	}
+
+	if !claimed || alert.User == nil {
+		return nil
+	}
+
+	jobs, err := s.alertRepository.GetNewJobs(ctx, alert, domain.JobAlertMaxJobs)
+
+	if err != nil {
+		return err
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	deliveries := make([]entities.JobAlertDelivery, 0, len(jobs))
+	lastRunAt := alert.LastRunAt
+	for _, job := range jobs {
+		deliveries = append(deliveries, entities.JobAlertDelivery{
+			UserID:  alert.UserID,
+			JobID:   job.ID,
+			AlertID: alert.ID,
+		})
+
+		if job.OpenedAt != nil && job.OpenedAt.After(lastRunAt) {
+			lastRunAt = *job.OpenedAt
+		}
+	}
+
+	delivered, err := s.alertRepository.DeliverJobs(ctx, alert.ID, deliveries, entities.Notification{
+		UserID:           alert.UserID,
+		Title:            "New jobs for " + alert.Name,
+		Message:          summarizeJobs(jobs),
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeJobAlert,
+		ReferenceID:      &alert.ID,
+	}, lastRunAt)
+
+	if err != nil || !delivered {
+		return err
+	}
+
+	return mailing.SendMail(alert.User.Email, alertSubject(alert, len(jobs)), alertEmail(alert, jobs, appURL))
+}
+
+func companyName(job entities.Job) string {
+	if job.Company == nil {
+		return ""
+	}
+	return job.Company.Name
+}
+
+func summarizeJobs(jobs []entities.Job) string {
+	summary := jobs[0].Title + " at " + companyName(jobs[0])
+
+	if len(jobs) > 1 {
+		summary += fmt.Sprintf(" and %d more", len(jobs)-1)
+	}
+
+	return summary
+}
+
+func alertSubject(alert entities.JobAlert, count int) string {
+	jobs := "jobs"
+	if count == 1 {
+		jobs = "job"
+	}
+
+	switch alert.Frequency {
+	case domain.JobAlertFrequencyDaily, domain.JobAlertFrequencyWeekly:
+		return fmt.Sprintf("Your %s job alert: %d new %s for %s", alert.Frequency, count, jobs, alert.Name)
+	default:
+		return fmt.Sprintf("%d new %s for %s", count, jobs, alert.Name)
+	}
+}
+
+func alertEmail(alert entities.JobAlert, jobs []entities.Job, appURL string) string {
+	var body strings.Builder
+
+	body.WriteString("<p>New jobs matching your alert &quot;" + html.EscapeString(alert.Name) + "&quot;:</p><ul>")
+
+	for _, job := range jobs {
+		body.WriteString(fmt.Sprintf(`<li><a href="%s/jobs/%s">%s</a> at %s`,
+			html.EscapeString(appURL), job.ID, html.EscapeString(job.Title), html.EscapeString(companyName(job))))
+
+		if job.Location != "" {
+			body.WriteString(" &middot; " + html.EscapeString(job.Location))
+		}
+
+		body.WriteString("</li>")
+	}
+
+	body.WriteString("</ul>")
+
+	return body.String()
+}
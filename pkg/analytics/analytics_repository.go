@@ -0,0 +1,226 @@
+package analytics
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	AnalyticsRepository interface {
+		GetAuthoredPost(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (entities.Post, error)
+		GetVisiblePostAuthor(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (uuid.UUID, error)
+		GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error)
+		RecordImpressions(ctx context.Context, impressions []entities.PostImpression) error
+		RecordProfileClick(ctx context.Context, click entities.PostProfileClick) error
+		GetImpressionCounts(ctx context.Context, postID uuid.UUID, since time.Time) ([]ImpressionCount, error)
+		CountUniqueViewers(ctx context.Context, postID uuid.UUID, since time.Time) (int64, error)
+		CountProfileClicks(ctx context.Context, postID uuid.UUID, since time.Time) (int64, error)
+		CountEngagement(ctx context.Context, postID uuid.UUID, since time.Time) (Engagement, error)
+		GetViewerTitles(ctx context.Context, postID uuid.UUID, since time.Time, limit int) ([]ViewerBreakdown, error)
+		GetViewerCompanies(ctx context.Context, postID uuid.UUID, since time.Time, limit int) ([]ViewerBreakdown, error)
+	}
+
+	analyticsRepository struct {
+		db *gorm.DB
+	}
+
+	ImpressionCount struct {
+		ViewedOn time.Time
+		Count    int64
+	}
+
+	Engagement struct {
+		Reactions int64
+		Comments  int64
+		Shares    int64
+	}
+
+	ViewerBreakdown struct {
+		Name    string
+		Viewers int64
+	}
+)
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+func (r *analyticsRepository) GetAuthoredPost(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (entities.Post, error) {
+	var post entities.Post
+	if err := r.db.WithContext(ctx).First(&post, "id = ? AND user_id = ?", postID, userID).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *analyticsRepository) GetVisiblePostAuthor(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (uuid.UUID, error) {
+	var authorIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Scopes(visibility.VisibleTo(viewerID, "posts")).
+		Where("posts.id = ?", postID).
+		Limit(1).
+		Pluck("posts.user_id", &authorIDs).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(authorIDs) == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+	return authorIDs[0], nil
+}
+
+func (r *analyticsRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+// RecordImpressions adds a batch of impressions to the daily counters.
+func (r *analyticsRepository) RecordImpressions(ctx context.Context, impressions []entities.PostImpression) error {
+	if len(impressions) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "post_id"}, {Name: "viewer_id"}, {Name: "viewed_on"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"count":      gorm.Expr("post_impressions.count + excluded.count"),
+				"updated_at": gorm.Expr("excluded.updated_at"),
+			}),
+		}).
+		CreateInBatches(&impressions, domain.ImpressionBatchSize).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RecordProfileClick stores at most one click per viewer, post and day.
+func (r *analyticsRepository) RecordProfileClick(ctx context.Context, click entities.PostProfileClick) error {
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&click).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *analyticsRepository) GetImpressionCounts(ctx context.Context, postID uuid.UUID, since time.Time) ([]ImpressionCount, error) {
+	var counts []ImpressionCount
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostImpression{}).
+		Select("viewed_on, SUM(count) AS count").
+		Where("post_id = ? AND viewed_on >= ?", postID, since).
+		Group("viewed_on").
+		Order("viewed_on").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *analyticsRepository) CountUniqueViewers(ctx context.Context, postID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostImpression{}).
+		Where("post_id = ? AND viewed_on >= ? AND viewer_id <> ?", postID, since, uuid.Nil).
+		Distinct("viewer_id").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *analyticsRepository) CountProfileClicks(ctx context.Context, postID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostProfileClick{}).
+		Where("post_id = ? AND clicked_on >= ?", postID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// engagementSQL counts interactions the same way as the stored engagement
+// of a post, through the post_engagement_counts database function.
+const engagementSQL = `SELECT reactions, comments, shares FROM post_engagement_counts(@post, @since)`
+
+// CountEngagement counts the reactions, comments and shares a post received
+// since the given time.
+func (r *analyticsRepository) CountEngagement(ctx context.Context, postID uuid.UUID, since time.Time) (Engagement, error) {
+	var engagement Engagement
+	if err := r.db.WithContext(ctx).
+		Raw(engagementSQL, map[string]interface{}{"post": postID, "since": since}).
+		Scan(&engagement).Error; err != nil {
+		return Engagement{}, err
+	}
+	return engagement, nil
+}
+
+// viewerExperienceSQL picks the latest experience of every signed in viewer
+// of a post.
+const viewerExperienceSQL = `WITH viewers AS (
+	SELECT DISTINCT viewer_id
+	FROM post_impressions
+	WHERE post_id = @post AND viewed_on >= @since AND viewer_id <> @anonymous AND deleted_at IS NULL
+), latest AS (
+	SELECT DISTINCT ON (ue.user_id) ue.user_id, ue.title, ue.company_id
+	FROM user_experiences ue
+	JOIN viewers v ON v.viewer_id = ue.user_id
+	WHERE ue.deleted_at IS NULL
+	ORDER BY ue.user_id, ue.started_at DESC
+)`
+
+const viewerTitlesSQL = viewerExperienceSQL + `
+SELECT latest.title AS name, COUNT(*) AS viewers
+FROM latest
+WHERE latest.title <> ''
+GROUP BY latest.title
+ORDER BY viewers DESC, name
+LIMIT @limit`
+
+const viewerCompaniesSQL = viewerExperienceSQL + `
+SELECT c.name AS name, COUNT(*) AS viewers
+FROM latest
+JOIN companies c ON c.id = latest.company_id AND c.deleted_at IS NULL
+GROUP BY c.name
+ORDER BY viewers DESC, name
+LIMIT @limit`
+
+func (r *analyticsRepository) getViewerBreakdown(ctx context.Context, query string, postID uuid.UUID, since time.Time, limit int) ([]ViewerBreakdown, error) {
+	var breakdown []ViewerBreakdown
+	if err := r.db.WithContext(ctx).
+		Raw(query, map[string]interface{}{
+			"post":      postID,
+			"since":     since,
+			"anonymous": uuid.Nil,
+			"limit":     limit,
+		}).
+		Scan(&breakdown).Error; err != nil {
+		return nil, err
+	}
+	return breakdown, nil
+}
+
+// GetViewerTitles groups the signed in viewers of a post by their current
+// job title.
+func (r *analyticsRepository) GetViewerTitles(ctx context.Context, postID uuid.UUID, since time.Time, limit int) ([]ViewerBreakdown, error) {
+	return r.getViewerBreakdown(ctx, viewerTitlesSQL, postID, since, limit)
+}
+
+// GetViewerCompanies groups the signed in viewers of a post by the company
+// they currently work at.
+func (r *analyticsRepository) GetViewerCompanies(ctx context.Context, postID uuid.UUID, since time.Time, limit int) ([]ViewerBreakdown, error) {
+	return r.getViewerBreakdown(ctx, viewerCompaniesSQL, postID, since, limit)
+}
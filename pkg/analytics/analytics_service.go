@@ -0,0 +1,210 @@
+package analytics
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	AnalyticsService interface {
+		GetPostAnalytics(ctx context.Context, postID string, userID string, days int) (domain.PostAnalyticsResponse, error)
+		RecordProfileClick(ctx context.Context, postID string, viewerID string) error
+		FlushImpressions(ctx context.Context) error
+	}
+
+	analyticsService struct {
+		analyticsRepository AnalyticsRepository
+		impressionBuffer    *ImpressionBuffer
+		jwtService          jwtService.JWTService
+	}
+)
+
+func NewAnalyticsService(analyticsRepository AnalyticsRepository, impressionBuffer *ImpressionBuffer, jwtService jwtService.JWTService) AnalyticsService {
+	return &analyticsService{
+		analyticsRepository: analyticsRepository,
+		impressionBuffer:    impressionBuffer,
+		jwtService:          jwtService,
+	}
+}
+
+func (s *analyticsService) GetPostAnalytics(ctx context.Context, postID string, userID string, days int) (domain.PostAnalyticsResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrParseUUID
+	}
+
+	if days <= 0 {
+		days = domain.PostAnalyticsDefaultDays
+	}
+
+	if days > domain.PostAnalyticsMaxDays {
+		days = domain.PostAnalyticsMaxDays
+	}
+
+	post, err := s.analyticsRepository.GetAuthoredPost(ctx, parsedPostID, parsedUserID)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrPostNotFound
+	}
+
+	user, err := s.analyticsRepository.GetUserByID(ctx, parsedUserID)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrUserNotFound
+	}
+
+	today := utils.StartOfDay(time.Now())
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.analyticsRepository.GetImpressionCounts(ctx, post.ID, since)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	uniqueViewers, err := s.analyticsRepository.CountUniqueViewers(ctx, post.ID, since)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	profileClicks, err := s.analyticsRepository.CountProfileClicks(ctx, post.ID, since)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	engagement, err := s.analyticsRepository.CountEngagement(ctx, post.ID, since)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	countsByDate := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countsByDate[count.ViewedOn.Format("2006-01-02")] = count.Count
+	}
+
+	var impressions int64
+	daily := make([]domain.PostImpressionCountResponse, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		count := countsByDate[day.Format("2006-01-02")]
+		impressions += count
+		daily = append(daily, domain.PostImpressionCountResponse{
+			Date:        utils.ConvertTimeToString(day),
+			Impressions: count,
+		})
+	}
+
+	total := engagement.Reactions + engagement.Comments + engagement.Shares
+
+	res := domain.PostAnalyticsResponse{
+		PostID:          post.ID.String(),
+		Days:            days,
+		Impressions:     impressions,
+		UniqueViewers:   uniqueViewers,
+		ProfileClicks:   profileClicks,
+		Reactions:       engagement.Reactions,
+		Comments:        engagement.Comments,
+		Shares:          engagement.Shares,
+		Engagement:      total,
+		Daily:           daily,
+		BreakdownLocked: !user.IsPremium,
+		ViewerTitles:    []domain.ViewerBreakdownResponse{},
+		ViewerCompanies: []domain.ViewerBreakdownResponse{},
+	}
+
+	if impressions > 0 {
+		res.EngagementRate = float64(total) / float64(impressions)
+	}
+
+	if !user.IsPremium {
+		return res, nil
+	}
+
+	titles, err := s.analyticsRepository.GetViewerTitles(ctx, post.ID, since, domain.PostAnalyticsBreakdowns)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	companies, err := s.analyticsRepository.GetViewerCompanies(ctx, post.ID, since, domain.PostAnalyticsBreakdowns)
+
+	if err != nil {
+		return domain.PostAnalyticsResponse{}, domain.ErrGetPostAnalytics
+	}
+
+	for _, title := range titles {
+		res.ViewerTitles = append(res.ViewerTitles, domain.ViewerBreakdownResponse{Name: title.Name, Viewers: title.Viewers})
+	}
+
+	for _, company := range companies {
+		res.ViewerCompanies = append(res.ViewerCompanies, domain.ViewerBreakdownResponse{Name: company.Name, Viewers: company.Viewers})
+	}
+
+	return res, nil
+}
+
+// RecordProfileClick counts a visit to the author's profile from one of
+// their posts. Authors clicking through their own posts are not counted.
+func (s *analyticsService) RecordProfileClick(ctx context.Context, postID string, viewerID string) error {
+	parsedViewerID, err := uuid.Parse(viewerID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	authorID, err := s.analyticsRepository.GetVisiblePostAuthor(ctx, parsedPostID, parsedViewerID)
+
+	if err != nil {
+		return domain.ErrPostNotFound
+	}
+
+	if authorID == parsedViewerID {
+		return nil
+	}
+
+	err = s.analyticsRepository.RecordProfileClick(ctx, entities.PostProfileClick{
+		PostID:    parsedPostID,
+		ViewerID:  parsedViewerID,
+		ClickedOn: utils.StartOfDay(time.Now()),
+	})
+
+	if err != nil {
+		return domain.ErrRecordProfileClick
+	}
+
+	return nil
+}
+
+// FlushImpressions writes the buffered feed impressions in batches. It is
+// run periodically by the scheduler started in the app config.
+func (s *analyticsService) FlushImpressions(ctx context.Context) error {
+	impressions := s.impressionBuffer.drain()
+
+	if err := s.analyticsRepository.RecordImpressions(ctx, impressions); err != nil {
+		s.impressionBuffer.restore(impressions)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type impressionKey struct {
+	PostID   uuid.UUID
+	ViewerID uuid.UUID
+	ViewedOn time.Time
+}
+
+// ImpressionBuffer collects feed impressions in memory so serving a feed
+// never waits on a write. The analytics service flushes it periodically.
+type ImpressionBuffer struct {
+	mu     sync.Mutex
+	counts map[impressionKey]int64
+}
+
+func NewImpressionBuffer() *ImpressionBuffer {
+	return &ImpressionBuffer{counts: make(map[impressionKey]int64)}
+}
+
+// Add counts one impression of every post for the viewer, who is uuid.Nil
+// when signed out.
+func (b *ImpressionBuffer) Add(postIDs []uuid.UUID, viewerID uuid.UUID) {
+	today := utils.StartOfDay(time.Now())
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, postID := range postIDs {
+		b.counts[impressionKey{PostID: postID, ViewerID: viewerID, ViewedOn: today}]++
+	}
+}
+
+// drain empties the buffer and returns what it held.
+func (b *ImpressionBuffer) drain() []entities.PostImpression {
+	b.mu.Lock()
+	counts := b.counts
+	b.counts = make(map[impressionKey]int64)
+	b.mu.Unlock()
+
+	impressions := make([]entities.PostImpression, 0, len(counts))
+	for key, count := range counts {
+		impressions = append(impressions, entities.PostImpression{
+			PostID:   key.PostID,
+			ViewerID: key.ViewerID,
+			ViewedOn: key.ViewedOn,
+			Count:    count,
+		})
+	}
+	return impressions
+}
+
+// restore puts back impressions that could not be written, to be retried on
+// the next flush.
+func (b *ImpressionBuffer) restore(impressions []entities.PostImpression) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, impression := range impressions {
+		b.counts[impressionKey{PostID: impression.PostID, ViewerID: impression.ViewerID, ViewedOn: impression.ViewedOn}] += impression.Count
+	}
+}
@@ -0,0 +1,129 @@
+package attachment
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils/storage"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime/multipart"
+
+	"gorm.io/gorm"
+)
+
+var kinds = map[string]string{
+	"image/jpeg":      domain.AttachmentKindImage,
+	"image/png":       domain.AttachmentKindImage,
+	"image/gif":       domain.AttachmentKindImage,
+	"application/pdf": domain.AttachmentKindDocument,
+	"video/mp4":       domain.AttachmentKindVideo,
+	"video/webm":      domain.AttachmentKindVideo,
+}
+
+var maxSizes = map[string]int64{
+	domain.AttachmentKindImage:    domain.PostMaxImageSize,
+	domain.AttachmentKindDocument: domain.PostMaxDocumentSize,
+	domain.AttachmentKindVideo:    domain.PostMaxVideoSize,
+}
+
+// Upload is an attachment file that passed inspection and is ready to be
+// stored.
+type Upload struct {
+	File     *multipart.FileHeader
+	MimeType string
+	Kind     string
+	Width    int
+	Height   int
+}
+
+// Inspect detects the type and dimensions of each file and checks that they
+// form a valid set: up to PostMaxImages images, or a single document or
+// video.
+func Inspect(files []*multipart.FileHeader) ([]Upload, error) {
+	uploads := make([]Upload, 0, len(files))
+
+	for _, file := range files {
+		upload, err := inspect(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(files) > 1 && upload.Kind != domain.AttachmentKindImage {
+			return nil, domain.ErrMixedAttachments
+		}
+
+		uploads = append(uploads, upload)
+	}
+
+	if len(uploads) > domain.PostMaxImages {
+		return nil, domain.ErrTooManyAttachments
+	}
+
+	return uploads, nil
+}
+
+func inspect(fileHeader *multipart.FileHeader) (Upload, error) {
+	file, err := fileHeader.Open()
+
+	if err != nil {
+		return Upload{}, domain.ErrInspectAttachment
+	}
+	defer file.Close()
+
+	mimeType, err := storage.GetMimetype(file)
+
+	if err != nil {
+		return Upload{}, domain.ErrInspectAttachment
+	}
+
+	kind, ok := kinds[mimeType]
+
+	if !ok {
+		return Upload{}, domain.ErrAttachmentType
+	}
+
+	if fileHeader.Size > maxSizes[kind] {
+		return Upload{}, domain.ErrAttachmentTooLarge
+	}
+
+	upload := Upload{File: fileHeader, MimeType: mimeType, Kind: kind}
+
+	if kind == domain.AttachmentKindImage {
+		config, _, err := image.DecodeConfig(file)
+
+		if err != nil {
+			return Upload{}, domain.ErrInspectAttachment
+		}
+
+		upload.Width = config.Width
+		upload.Height = config.Height
+	}
+
+	return upload, nil
+}
+
+// InOrder sorts preloaded attachments by their position in the post.
+func InOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+func ToAttachmentResponses(attachments []entities.PostAttachment) []domain.PostAttachmentResponse {
+	responses := make([]domain.PostAttachmentResponse, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		responses = append(responses, domain.PostAttachmentResponse{
+			URL:      attachment.URL,
+			Kind:     kinds[attachment.MimeType],
+			MimeType: attachment.MimeType,
+			Size:     attachment.Size,
+			Width:    attachment.Width,
+			Height:   attachment.Height,
+			Position: attachment.Position,
+		})
+	}
+
+	return responses
+}
@@ -0,0 +1,116 @@
+package block
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	BlockRepository interface {
+		CreateBlock(ctx context.Context, block entities.UserBlock) error
+		DeleteBlock(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID, blockType string) (bool, error)
+		IsBlockedBetween(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (bool, error)
+		GetBlocks(ctx context.Context, userID uuid.UUID, blockType string) ([]entities.UserBlock, error)
+	}
+
+	blockRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewBlockRepository(db *gorm.DB) BlockRepository {
+	return &blockRepository{db: db}
+}
+
+// ExcludeBlocked drops rows whose userColumn belongs to someone who blocked,
+// or was blocked by, the viewer. Anonymous viewers are not filtered.
+func ExcludeBlocked(viewerID uuid.UUID, userColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db
+		}
+		return db.Where(`NOT EXISTS (
+			SELECT 1 FROM user_blocks ub
+			WHERE ub.type = ? AND (
+				(ub.user_id = ? AND ub.target_user_id = `+userColumn+`) OR
+				(ub.user_id = `+userColumn+` AND ub.target_user_id = ?)
+			))`, domain.BlockTypeBlock, viewerID, viewerID)
+	}
+}
+
+// ExcludeHidden is ExcludeBlocked plus the users the viewer has muted. It is
+// meant for content such as posts, where muting hides without blocking.
+func ExcludeHidden(viewerID uuid.UUID, userColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db
+		}
+		return db.Where(`NOT EXISTS (
+			SELECT 1 FROM user_blocks ub
+			WHERE (ub.user_id = ? AND ub.target_user_id = `+userColumn+`) OR
+				(ub.type = ? AND ub.user_id = `+userColumn+` AND ub.target_user_id = ?)
+			)`, viewerID, domain.BlockTypeBlock, viewerID)
+	}
+}
+
+// CreateBlock stores the block or mute. Blocking also drops any connection
+// or pending request between the two users.
+func (r *blockRepository) CreateBlock(ctx context.Context, block entities.UserBlock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block).Error; err != nil {
+			return err
+		}
+
+		if block.Type != domain.BlockTypeBlock {
+			return nil
+		}
+
+		if err := tx.Unscoped().
+			Where("(user_id = ? AND connected_with_id = ?) OR (user_id = ? AND connected_with_id = ?)",
+				block.UserID, block.TargetUserID, block.TargetUserID, block.UserID).
+			Delete(&entities.UserConnection{}).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *blockRepository) DeleteBlock(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID, blockType string) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND target_user_id = ? AND type = ?", userID, targetUserID, blockType).
+		Delete(&entities.UserBlock{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *blockRepository) IsBlockedBetween(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.UserBlock{}).
+		Where("type = ? AND ((user_id = ? AND target_user_id = ?) OR (user_id = ? AND target_user_id = ?))",
+			domain.BlockTypeBlock, userID, targetUserID, targetUserID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *blockRepository) GetBlocks(ctx context.Context, userID uuid.UUID, blockType string) ([]entities.UserBlock, error) {
+	var blocks []entities.UserBlock
+	if err := r.db.WithContext(ctx).
+		Preload("TargetUser").
+		Where("user_id = ? AND type = ?", userID, blockType).
+		Order("created_at desc").
+		Find(&blocks).Error; err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
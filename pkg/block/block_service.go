@@ -0,0 +1,152 @@
+package block
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type (
+	BlockService interface {
+		BlockUser(ctx context.Context, userID string, targetUserID string) error
+		UnblockUser(ctx context.Context, userID string, targetUserID string) error
+		MuteUser(ctx context.Context, userID string, targetUserID string) error
+		UnmuteUser(ctx context.Context, userID string, targetUserID string) error
+		GetBlockedUsers(ctx context.Context, userID string) ([]domain.BlockedUserResponse, error)
+		GetMutedUsers(ctx context.Context, userID string) ([]domain.BlockedUserResponse, error)
+	}
+
+	blockService struct {
+		blockRepository BlockRepository
+		jwtService      jwtService.JWTService
+	}
+)
+
+func NewBlockService(blockRepository BlockRepository, jwtService jwtService.JWTService) BlockService {
+	return &blockService{
+		blockRepository: blockRepository,
+		jwtService:      jwtService,
+	}
+}
+
+func parseUserPair(userID string, targetUserID string) (uuid.UUID, uuid.UUID, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrParseUUID
+	}
+
+	parsedTargetUserID, err := uuid.Parse(targetUserID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrParseUUID
+	}
+
+	if parsedUserID == parsedTargetUserID {
+		return uuid.Nil, uuid.Nil, domain.ErrBlockSelf
+	}
+
+	return parsedUserID, parsedTargetUserID, nil
+}
+
+func (s *blockService) createBlock(ctx context.Context, userID string, targetUserID string, blockType string) error {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.blockRepository.CreateBlock(ctx, entities.UserBlock{
+		UserID:       parsedUserID,
+		TargetUserID: parsedTargetUserID,
+		Type:         blockType,
+	})
+
+	if err != nil {
+		return domain.ErrBlockUser
+	}
+
+	return nil
+}
+
+func (s *blockService) deleteBlock(ctx context.Context, userID string, targetUserID string, blockType string, notFound error) error {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.blockRepository.DeleteBlock(ctx, parsedUserID, parsedTargetUserID, blockType)
+
+	if err != nil {
+		return domain.ErrUnblockUser
+	}
+
+	if !deleted {
+		return notFound
+	}
+
+	return nil
+}
+
+func (s *blockService) getBlocks(ctx context.Context, userID string, blockType string) ([]domain.BlockedUserResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	blocks, err := s.blockRepository.GetBlocks(ctx, parsedUserID, blockType)
+
+	if err != nil {
+		return nil, domain.ErrGetBlockedUsers
+	}
+
+	blockedUsers := make([]domain.BlockedUserResponse, 0, len(blocks))
+
+	for _, block := range blocks {
+		if block.TargetUser == nil {
+			continue
+		}
+
+		blockedUsers = append(blockedUsers, domain.BlockedUserResponse{
+			ID:             block.TargetUser.ID.String(),
+			Name:           block.TargetUser.Name,
+			Slug:           block.TargetUser.Slug,
+			ProfilePicture: block.TargetUser.ProfilePicture,
+			Headline:       block.TargetUser.CurrentTitle,
+			Type:           block.Type,
+			Since:          utils.ConvertTimeToString(block.CreatedAt),
+		})
+	}
+
+	return blockedUsers, nil
+}
+
+func (s *blockService) BlockUser(ctx context.Context, userID string, targetUserID string) error {
+	return s.createBlock(ctx, userID, targetUserID, domain.BlockTypeBlock)
+}
+
+func (s *blockService) UnblockUser(ctx context.Context, userID string, targetUserID string) error {
+	return s.deleteBlock(ctx, userID, targetUserID, domain.BlockTypeBlock, domain.ErrBlockNotFound)
+}
+
+func (s *blockService) MuteUser(ctx context.Context, userID string, targetUserID string) error {
+	return s.createBlock(ctx, userID, targetUserID, domain.BlockTypeMute)
+}
+
+func (s *blockService) UnmuteUser(ctx context.Context, userID string, targetUserID string) error {
+	return s.deleteBlock(ctx, userID, targetUserID, domain.BlockTypeMute, domain.ErrMuteNotFound)
+}
+
+func (s *blockService) GetBlockedUsers(ctx context.Context, userID string) ([]domain.BlockedUserResponse, error) {
+	return s.getBlocks(ctx, userID, domain.BlockTypeBlock)
+}
+
+func (s *blockService) GetMutedUsers(ctx context.Context, userID string) ([]domain.BlockedUserResponse, error) {
+	return s.getBlocks(ctx, userID, domain.BlockTypeMute)
+}
@@ -3,7 +3,9 @@ package chat
 import (
 	"gorm.io/gorm"
 
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
 	"context"
 
 	"github.com/google/uuid"
@@ -15,7 +17,7 @@ type (
 		GetChatRoom(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (entities.ChatRoom, error)
 		CreateChatRoom(ctx context.Context, chatRoom entities.ChatRoom) error
 		CreateMessage(ctx context.Context, message entities.ChatMessage) error
-		GetMessages(ctx context.Context, roomID uuid.UUID) ([]entities.ChatMessage, error)
+		GetMessages(ctx context.Context, roomID uuid.UUID, pagination domain.PaginationRequest) ([]entities.ChatMessage, error)
 		GetChatRoomByRoomID(ctx context.Context, roomID uuid.UUID) (entities.ChatRoom, error)
 		CheckUserExistInChatRoom(ctx context.Context, roomID uuid.UUID, userID uuid.UUID) (bool, error)
 	}
@@ -67,9 +69,20 @@ func (r *chatRepository) CreateMessage(ctx context.Context, message entities.Cha
 	return nil
 }
 
-func (r *chatRepository) GetMessages(ctx context.Context, roomID uuid.UUID) ([]entities.ChatMessage, error) {
+func (r *chatRepository) GetMessages(ctx context.Context, roomID uuid.UUID, pagination domain.PaginationRequest) ([]entities.ChatMessage, error) {
 	var messages []entities.ChatMessage
-	if err := r.db.WithContext(ctx).Preload("User").Where("room_id = ?", roomID).Find(&messages).Error; err != nil {
+
+	query := r.db.WithContext(ctx).Preload("User").Where("room_id = ?", roomID)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&messages).Error; err != nil {
 		return nil, err
 	}
 	return messages, nil
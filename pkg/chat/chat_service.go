@@ -3,9 +3,12 @@ package chat
 import (
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/block"
 	jwtService "Go-Starter-Template/pkg/jwt"
 	"Go-Starter-Template/pkg/notification"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
@@ -15,18 +18,19 @@ type (
 		GetChatRooms(ctx context.Context, userID string) ([]domain.ChatRoomsResponse, error)
 		GetChatRoom(ctx context.Context, userID string, targetUserID string) (domain.ChatRoomResponse, error)
 		SendMessage(ctx context.Context, req domain.CreateMessageRequest, userID string) error
-		GetMessages(ctx context.Context, userID string, roomID string) (domain.ChatRoomMessageResponse, error)
+		GetMessages(ctx context.Context, userID string, roomID string, pagination domain.PaginationRequest) (domain.ChatRoomMessageResponse, domain.PaginationResponse, error)
 	}
 
 	chatService struct {
 		chatRepository         ChatRepository
 		notificationRepository notification.NotificationRepository
+		blockRepository        block.BlockRepository
 		jwtService             jwtService.JWTService
 	}
 )
 
-func NewChatService(chatRepository ChatRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) ChatService {
-	return &chatService{chatRepository: chatRepository, notificationRepository: notificationRepository, jwtService: jwtService}
+func NewChatService(chatRepository ChatRepository, notificationRepository notification.NotificationRepository, blockRepository block.BlockRepository, jwtService jwtService.JWTService) ChatService {
+	return &chatService{chatRepository: chatRepository, notificationRepository: notificationRepository, blockRepository: blockRepository, jwtService: jwtService}
 }
 
 func (s *chatService) GetChatRooms(ctx context.Context, userID string) ([]domain.ChatRoomsResponse, error) {
@@ -81,6 +85,16 @@ func (s *chatService) GetChatRoom(ctx context.Context, userID string, targetUser
 		return domain.ChatRoomResponse{}, domain.ErrParseUUID
 	}
 
+	blocked, err := s.blockRepository.IsBlockedBetween(ctx, parsedUserID, parsedTargetUserID)
+
+	if err != nil {
+		return domain.ChatRoomResponse{}, domain.ErrCheckBlock
+	}
+
+	if blocked {
+		return domain.ChatRoomResponse{}, domain.ErrUserBlocked
+	}
+
 	chatRoom, err := s.chatRepository.GetChatRoom(ctx, parsedUserID, parsedTargetUserID)
 
 	if err != nil {
@@ -125,6 +139,22 @@ func (s *chatService) SendMessage(ctx context.Context, req domain.CreateMessageR
 		return domain.ErrUserNotExistInChatRoom
 	}
 
+	chatRoom, err := s.chatRepository.GetChatRoomByRoomID(ctx, parsedChatRoomID)
+
+	if err != nil {
+		return domain.ErrFailedGetChatRoom
+	}
+
+	blocked, err := s.blockRepository.IsBlockedBetween(ctx, chatRoom.FirstUserID, chatRoom.SecondUserID)
+
+	if err != nil {
+		return domain.ErrCheckBlock
+	}
+
+	if blocked {
+		return domain.ErrUserBlocked
+	}
+
 	err = s.chatRepository.CreateMessage(ctx, entities.ChatMessage{
 		RoomID:  parsedChatRoomID,
 		UserID:  parsedUserID,
@@ -135,11 +165,6 @@ func (s *chatService) SendMessage(ctx context.Context, req domain.CreateMessageR
 		return domain.ErrFailedCreateMessage
 	}
 
-	chatRoom, err := s.chatRepository.GetChatRoomByRoomID(ctx, parsedChatRoomID)
-
-	if err != nil {
-		return err
-	}
 	var targetUser entities.User
 
 	var senderUser entities.User
@@ -173,47 +198,61 @@ func (s *chatService) SendMessage(ctx context.Context, req domain.CreateMessageR
 	return nil
 }
 
-func (s *chatService) GetMessages(ctx context.Context, userID string, roomID string) (domain.ChatRoomMessageResponse, error) {
+func (s *chatService) GetMessages(ctx context.Context, userID string, roomID string, pagination domain.PaginationRequest) (domain.ChatRoomMessageResponse, domain.PaginationResponse, error) {
 	parsedUserID, err := uuid.Parse(userID)
 
 	if err != nil {
-		return domain.ChatRoomMessageResponse{}, domain.ErrParseUUID
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, domain.ErrParseUUID
 	}
 
 	parsedRoomID, err := uuid.Parse(roomID)
 
 	if err != nil {
-		return domain.ChatRoomMessageResponse{}, domain.ErrParseUUID
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, domain.ErrParseUUID
 	}
 
 	exist, err := s.chatRepository.CheckUserExistInChatRoom(ctx, parsedRoomID, parsedUserID)
 
 	if exist == false {
-		return domain.ChatRoomMessageResponse{}, domain.ErrUserNotExistInChatRoom
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, domain.ErrUserNotExistInChatRoom
 	}
 
 	chatRoom, err := s.chatRepository.GetChatRoomByRoomID(ctx, parsedRoomID)
 
 	if err != nil {
-		return domain.ChatRoomMessageResponse{}, domain.ErrFailedGetChatRoom
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, domain.ErrFailedGetChatRoom
+	}
+
+	messages, err := s.chatRepository.GetMessages(ctx, parsedRoomID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return domain.ChatRoomMessageResponse{}, domain.PaginationResponse{}, domain.ErrFailedGetMessages
 	}
 
-	messages, err := s.chatRepository.GetMessages(ctx, parsedRoomID)
+	messages, hasMore := utils.TrimPage(messages, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		oldest := messages[len(messages)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(oldest.CreatedAt, oldest.ID), HasMore: true}
+	}
 
 	var chatMessageResponse []domain.ChatMessageResponse
 
-	for _, message := range messages {
+	// Pages are fetched newest first but shown oldest first.
+	for i := len(messages) - 1; i >= 0; i-- {
 		chatMessageResponse = append(chatMessageResponse, domain.ChatMessageResponse{
-			Message:        message.Message,
-			Sender:         message.User.Name,
-			ProfilePicture: message.User.ProfilePicture,
+			Message:        messages[i].Message,
+			Sender:         messages[i].User.Name,
+			ProfilePicture: messages[i].User.ProfilePicture,
 		})
 	}
 
-	if err != nil {
-		return domain.ChatRoomMessageResponse{}, domain.ErrFailedGetMessages
-	}
-
 	if chatMessageResponse == nil {
 		chatMessageResponse = []domain.ChatMessageResponse{}
 	}
@@ -224,13 +263,13 @@ func (s *chatService) GetMessages(ctx context.Context, userID string, roomID str
 			Name:           chatRoom.SecondUser.Name,
 			ProfilePicture: chatRoom.SecondUser.ProfilePicture,
 			Messages:       chatMessageResponse,
-		}, nil
+		}, meta, nil
 	} else {
 		return domain.ChatRoomMessageResponse{
 			ID:             chatRoom.ID.String(),
 			Name:           chatRoom.FirstUser.Name,
 			ProfilePicture: chatRoom.FirstUser.ProfilePicture,
 			Messages:       chatMessageResponse,
-		}, nil
+		}, meta, nil
 	}
 }
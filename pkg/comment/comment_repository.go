@@ -0,0 +1,144 @@
+package comment
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	CommentRepository interface {
+		GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error)
+		CreateComment(ctx context.Context, comment entities.PostComment) (entities.PostComment, error)
+		GetCommentByID(ctx context.Context, commentID uuid.UUID) (entities.PostComment, error)
+		UpdateComment(ctx context.Context, comment entities.PostComment) error
+		DeleteComment(ctx context.Context, commentID uuid.UUID) error
+		GetComments(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]entities.PostComment, error)
+		CountReplies(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+		CountComments(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	}
+
+	commentRepository struct {
+		db *gorm.DB
+	}
+
+	commentCountRow struct {
+		ID    uuid.UUID
+		Count int64
+	}
+)
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// GetPostByID finds a post the viewer is allowed to see.
+func (r *commentRepository) GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error) {
+	var post entities.Post
+	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		First(&post, "id = ?", postID).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *commentRepository) CreateComment(ctx context.Context, comment entities.PostComment) (entities.PostComment, error) {
+	if err := r.db.WithContext(ctx).Create(&comment).Error; err != nil {
+		return entities.PostComment{}, err
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) GetCommentByID(ctx context.Context, commentID uuid.UUID) (entities.PostComment, error) {
+	var comment entities.PostComment
+	if err := r.db.WithContext(ctx).Preload("User").First(&comment, "id = ?", commentID).Error; err != nil {
+		return entities.PostComment{}, err
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) UpdateComment(ctx context.Context, comment entities.PostComment) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.PostComment{}).
+		Where("id = ?", comment.ID).
+		Updates(map[string]interface{}{"content": comment.Content, "edited_at": comment.EditedAt}).Error
+}
+
+// DeleteComment soft deletes the comment together with its replies.
+func (r *commentRepository) DeleteComment(ctx context.Context, commentID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("id = ? OR parent_id = ?", commentID, commentID).
+		Delete(&entities.PostComment{}).Error
+}
+
+// GetComments lists the top-level comments of a post, or the replies to
+// parentID, oldest first.
+func (r *commentRepository) GetComments(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]entities.PostComment, error) {
+	var comments []entities.PostComment
+
+	query := r.db.WithContext(ctx).
+		Preload("User").
+		Scopes(block.ExcludeHidden(viewerID, "post_comments.user_id")).
+		Where("post_id = ? AND hidden_at IS NULL", postID)
+
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) > (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at ASC").Order("id ASC").Limit(pagination.Limit + 1).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) countBy(ctx context.Context, column string, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(ids))
+
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []commentCountRow
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostComment{}).
+		Select(column+" AS id, COUNT(*) AS count").
+		Where(column+" IN ? AND hidden_at IS NULL", ids).
+		Group(column).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *commentRepository) CountReplies(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	return r.countBy(ctx, "parent_id", commentIDs)
+}
+
+func (r *commentRepository) CountComments(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	return r.countBy(ctx, "post_id", postIDs)
+}
@@ -0,0 +1,322 @@
+package comment
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	CommentService interface {
+		CreateComment(ctx context.Context, req domain.CreateCommentRequest, userID string) (domain.CommentResponse, error)
+		UpdateComment(ctx context.Context, req domain.UpdateCommentRequest, userID string) error
+		DeleteComment(ctx context.Context, commentID string, userID string) error
+		GetComments(ctx context.Context, postID string, viewerID string, pagination domain.PaginationRequest) ([]domain.CommentResponse, domain.PaginationResponse, error)
+		GetReplies(ctx context.Context, commentID string, viewerID string, pagination domain.PaginationRequest) ([]domain.CommentResponse, domain.PaginationResponse, error)
+	}
+
+	commentService struct {
+		commentRepository      CommentRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewCommentService(commentRepository CommentRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) CommentService {
+	return &commentService{
+		commentRepository:      commentRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+func toCommentResponse(comment entities.PostComment, replyCount int64) domain.CommentResponse {
+	res := domain.CommentResponse{
+		ID:         comment.ID.String(),
+		PostID:     comment.PostID.String(),
+		UserID:     comment.UserID.String(),
+		Content:    comment.Content,
+		ReplyCount: replyCount,
+		IsEdited:   comment.EditedAt != nil,
+		CreatedAt:  utils.ConvertTimeToString(comment.CreatedAt),
+	}
+
+	if comment.ParentID != nil {
+		res.ParentID = comment.ParentID.String()
+	}
+
+	if comment.User != nil {
+		res.Name = comment.User.Name
+		res.Slug = comment.User.Slug
+		res.ProfilePicture = comment.User.ProfilePicture
+		res.Headline = comment.User.CurrentTitle
+	}
+
+	return res
+}
+
+func (s *commentService) CreateComment(ctx context.Context, req domain.CreateCommentRequest, userID string) (domain.CommentResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.CommentResponse{}, domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(req.PostID)
+
+	if err != nil {
+		return domain.CommentResponse{}, domain.ErrParseUUID
+	}
+
+	post, err := s.commentRepository.GetPostByID(ctx, parsedPostID, parsedUserID)
+
+	if err != nil {
+		return domain.CommentResponse{}, domain.ErrPostNotFound
+	}
+
+	comment := entities.PostComment{
+		PostID:  parsedPostID,
+		UserID:  parsedUserID,
+		Content: req.Content,
+	}
+
+	var parent entities.PostComment
+
+	if req.ParentID != "" {
+		parsedParentID, err := uuid.Parse(req.ParentID)
+
+		if err != nil {
+			return domain.CommentResponse{}, domain.ErrParseUUID
+		}
+
+		parent, err = s.commentRepository.GetCommentByID(ctx, parsedParentID)
+
+		if err != nil {
+			return domain.CommentResponse{}, domain.ErrCommentNotFound
+		}
+
+		if parent.PostID != parsedPostID {
+			return domain.CommentResponse{}, domain.ErrCommentPostMismatch
+		}
+
+		if parent.ParentID != nil {
+			return domain.CommentResponse{}, domain.ErrCommentReplyDepth
+		}
+
+		comment.ParentID = &parent.ID
+	}
+
+	comment, err = s.commentRepository.CreateComment(ctx, comment)
+
+	if err != nil {
+		return domain.CommentResponse{}, domain.ErrCreateComment
+	}
+
+	// The comment is stored by now, so nothing below fails the request: a
+	// retry would post it twice.
+	stored, err := s.commentRepository.GetCommentByID(ctx, comment.ID)
+
+	if err != nil {
+		log.Printf("load comment %s: %v", comment.ID, err)
+		return toCommentResponse(comment, 0), nil
+	}
+
+	s.notifyComment(ctx, post, parent, stored)
+
+	return toCommentResponse(stored, 0), nil
+}
+
+// notifyComment tells the post author about a new comment and, for replies,
+// the author of the parent comment. Nobody is notified about their own
+// comment or twice about the same one. Failures are only logged.
+func (s *commentService) notifyComment(ctx context.Context, post entities.Post, parent entities.PostComment, comment entities.PostComment) {
+	var notifications []entities.Notification
+
+	if comment.ParentID != nil && parent.UserID != comment.UserID {
+		notifications = append(notifications, entities.Notification{
+			UserID:           parent.UserID,
+			Title:            "New Reply",
+			Message:          comment.User.Name + " replied to your comment",
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeComment,
+			ReferenceID:      &post.ID,
+		})
+	}
+
+	if post.UserID != comment.UserID && (comment.ParentID == nil || post.UserID != parent.UserID) {
+		notifications = append(notifications, entities.Notification{
+			UserID:           post.UserID,
+			Title:            "New Comment",
+			Message:          comment.User.Name + " commented on your post",
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeComment,
+			ReferenceID:      &post.ID,
+		})
+	}
+
+	if err := s.notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		log.Printf("notify comment %s: %v", comment.ID, err)
+	}
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, req domain.UpdateCommentRequest, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedCommentID, err := uuid.Parse(req.ID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	comment, err := s.commentRepository.GetCommentByID(ctx, parsedCommentID)
+
+	if err != nil {
+		return domain.ErrCommentNotFound
+	}
+
+	if comment.UserID != parsedUserID {
+		return domain.ErrUserNotAllowed
+	}
+
+	editedAt := time.Now()
+	comment.Content = req.Content
+	comment.EditedAt = &editedAt
+
+	if err := s.commentRepository.UpdateComment(ctx, comment); err != nil {
+		return domain.ErrUpdateComment
+	}
+
+	return nil
+}
+
+// DeleteComment lets the comment author, or the author of the post it was
+// left on, remove a comment.
+func (s *commentService) DeleteComment(ctx context.Context, commentID string, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedCommentID, err := uuid.Parse(commentID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	comment, err := s.commentRepository.GetCommentByID(ctx, parsedCommentID)
+
+	if err != nil {
+		return domain.ErrCommentNotFound
+	}
+
+	if comment.UserID != parsedUserID {
+		post, err := s.commentRepository.GetPostByID(ctx, comment.PostID, parsedUserID)
+
+		if err != nil || post.UserID != parsedUserID {
+			return domain.ErrUserNotAllowed
+		}
+	}
+
+	if err := s.commentRepository.DeleteComment(ctx, parsedCommentID); err != nil {
+		return domain.ErrDeleteComment
+	}
+
+	return nil
+}
+
+func (s *commentService) listComments(ctx context.Context, postID uuid.UUID, parentID *uuid.UUID, viewerID string, pagination domain.PaginationRequest) ([]domain.CommentResponse, domain.PaginationResponse, error) {
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	comments, err := s.commentRepository.GetComments(ctx, postID, parentID, parsedViewerID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetComments
+	}
+
+	comments, hasMore := utils.TrimPage(comments, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := comments[len(comments)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	replyCounts := map[uuid.UUID]int64{}
+
+	if parentID == nil {
+		commentIDs := make([]uuid.UUID, 0, len(comments))
+		for _, comment := range comments {
+			commentIDs = append(commentIDs, comment.ID)
+		}
+
+		replyCounts, err = s.commentRepository.CountReplies(ctx, commentIDs)
+
+		if err != nil {
+			return nil, domain.PaginationResponse{}, domain.ErrGetComments
+		}
+	}
+
+	commentsResponse := make([]domain.CommentResponse, 0, len(comments))
+
+	for _, comment := range comments {
+		commentsResponse = append(commentsResponse, toCommentResponse(comment, replyCounts[comment.ID]))
+	}
+
+	return commentsResponse, meta, nil
+}
+
+func (s *commentService) GetComments(ctx context.Context, postID string, viewerID string, pagination domain.PaginationRequest) ([]domain.CommentResponse, domain.PaginationResponse, error) {
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+	}
+
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	if _, err := s.commentRepository.GetPostByID(ctx, parsedPostID, parsedViewerID); err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrPostNotFound
+	}
+
+	return s.listComments(ctx, parsedPostID, nil, viewerID, pagination)
+}
+
+func (s *commentService) GetReplies(ctx context.Context, commentID string, viewerID string, pagination domain.PaginationRequest) ([]domain.CommentResponse, domain.PaginationResponse, error) {
+	parsedCommentID, err := uuid.Parse(commentID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+	}
+
+	parent, err := s.commentRepository.GetCommentByID(ctx, parsedCommentID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrCommentNotFound
+	}
+
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	if _, err := s.commentRepository.GetPostByID(ctx, parent.PostID, parsedViewerID); err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrPostNotFound
+	}
+
+	return s.listComments(ctx, parent.PostID, &parent.ID, viewerID, pagination)
+}
@@ -1,8 +1,16 @@
 package company
 
 import (
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/job"
+	"Go-Starter-Template/pkg/poll"
+	"Go-Starter-Template/pkg/visibility"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -11,7 +19,10 @@ import (
 type (
 	CompanyRepository interface {
 		GetBySlug(ctx context.Context, slug string) (entities.Companies, error)
-		GetJobsByCompanyID(ctx context.Context, companyID uuid.UUID) ([]entities.Job, error)
+		GetJobsByCompanyID(ctx context.Context, companyID uuid.UUID, includeUnlisted bool) ([]entities.Job, error)
+		GetJobByID(ctx context.Context, jobID uuid.UUID, companyID uuid.UUID) (entities.Job, error)
+		ChangeJobStatus(ctx context.Context, job entities.Job, from string) (bool, error)
+		ExpireJobs(ctx context.Context, now time.Time, staleBefore time.Time) (int64, error)
 		GetJobSkillsByJobID(ctx context.Context, jobID uuid.UUID) ([]entities.JobSkill, error)
 		AddJob(ctx context.Context, job entities.Job) uuid.UUID
 		AddJobSkill(ctx context.Context, jobSkill entities.JobSkill) error
@@ -21,8 +32,15 @@ type (
 		RegisterCompany(ctx context.Context, company entities.Companies, user entities.User) error
 		GetCompanyByEmail(ctx context.Context, email string) (entities.User, entities.Companies, error)
 		GetCompanyByUserID(ctx context.Context, userID uuid.UUID) (entities.Companies, error)
-		GetPostsByCompanyID(ctx context.Context, companyID uuid.UUID) ([]entities.Post, error)
-		GetListCompany(ctx context.Context) ([]entities.Companies, error)
+		GetPostsByCompanyID(ctx context.Context, companyID uuid.UUID, viewerID uuid.UUID) ([]entities.Post, error)
+		GetListCompany(ctx context.Context, pagination domain.PaginationRequest) ([]entities.Companies, error)
+		GetCompanyByID(ctx context.Context, companyID uuid.UUID) (entities.Companies, error)
+		FollowCompany(ctx context.Context, follower entities.CompanyFollower) error
+		UnfollowCompany(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (bool, error)
+		IsFollowingCompany(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (bool, error)
+		CountFollowers(ctx context.Context, companyID uuid.UUID) (int64, error)
+		GetFollowedCompanies(ctx context.Context, userID uuid.UUID) ([]entities.CompanyFollower, error)
+		GetFollowerIDs(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error)
 	}
 	companyRepository struct {
 		db *gorm.DB
@@ -33,9 +51,22 @@ func NewCompanyRepository(db *gorm.DB) CompanyRepository {
 	return &companyRepository{db: db}
 }
 
-func (r *companyRepository) GetListCompany(ctx context.Context) ([]entities.Companies, error) {
+func (r *companyRepository) GetListCompany(ctx context.Context, pagination domain.PaginationRequest) ([]entities.Companies, error) {
 	var companies []entities.Companies
-	if err := r.db.WithContext(ctx).Find(&companies).Error; err != nil {
+
+	query := r.db.WithContext(ctx)
+
+	if pagination.Cursor != "" {
+		name, id, err := utils.DecodeKeyCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(name, id) > (?, ?)", name, id)
+	}
+
+	if err := query.Order("name ASC").Order("id ASC").Limit(pagination.Limit + 1).Find(&companies).Error; err != nil {
 		return nil, err
 	}
 	return companies, nil
@@ -106,19 +137,82 @@ func (r *companyRepository) UpdateProfile(ctx context.Context, company entities.
 	return nil
 }
 
-func (r *companyRepository) GetJobsByCompanyID(ctx context.Context, companyID uuid.UUID) ([]entities.Job, error) {
+// GetJobsByCompanyID lists the jobs of a company. Drafts, paused, closed and
+// expired jobs are only included for the company itself.
+func (r *companyRepository) GetJobsByCompanyID(ctx context.Context, companyID uuid.UUID, includeUnlisted bool) ([]entities.Job, error) {
 	var jobs []entities.Job
 
-	if err := r.db.WithContext(ctx).Where("company_id = ?", companyID).Find(&jobs).Error; err != nil {
+	query := r.db.WithContext(ctx).Where("company_id = ? AND hidden_at IS NULL", companyID)
+
+	if !includeUnlisted {
+		query = query.Scopes(job.Listed)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
 		return nil, err
 	}
 	return jobs, nil
 }
 
-func (r *companyRepository) GetPostsByCompanyID(ctx context.Context, companyID uuid.UUID) ([]entities.Post, error) {
+func (r *companyRepository) GetJobByID(ctx context.Context, jobID uuid.UUID, companyID uuid.UUID) (entities.Job, error) {
+	var job entities.Job
+	if err := r.db.WithContext(ctx).First(&job, "id = ? AND company_id = ?", jobID, companyID).Error; err != nil {
+		return entities.Job{}, err
+	}
+	return job, nil
+}
+
+// ChangeJobStatus moves a job on from the status it was read in. It reports
+// false when the status changed in the meantime, for example because the job
+// expired.
+func (r *companyRepository) ChangeJobStatus(ctx context.Context, job entities.Job, from string) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&entities.Job{}).
+		Where("id = ? AND status = ?", job.ID, from).
+		Updates(map[string]interface{}{
+			"status":    job.Status,
+			"deadline":  job.Deadline,
+			"opened_at": job.OpenedAt,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ExpireJobs expires the open and paused jobs whose deadline has passed, and
+// the ones without a deadline that have been open since before staleBefore.
+func (r *companyRepository) ExpireJobs(ctx context.Context, now time.Time, staleBefore time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).
+		Model(&entities.Job{}).
+		Where("status IN ?", []string{domain.JobStatusOpen, domain.JobStatusPaused}).
+		Where("(deadline IS NOT NULL AND deadline <= ?) OR (deadline IS NULL AND opened_at <= ?)", now, staleBefore).
+		Update("status", domain.JobStatusExpired)
+
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// GetPostsByCompanyID lists the posts of a company account that the viewer
+// is allowed to see.
+func (r *companyRepository) GetPostsByCompanyID(ctx context.Context, companyID uuid.UUID, viewerID uuid.UUID) ([]entities.Post, error) {
 	var posts []entities.Post
 
-	if err := r.db.WithContext(ctx).Preload("User").Order("created_at desc").Where("user_id = ?", companyID).Find(&posts).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Mentions").
+		Preload("Attachments", attachment.InOrder).
+		Preload("Poll.Options", poll.OptionsInOrder).
+		Preload("SharedPost", block.ExcludeHidden(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		Preload("SharedPost.User").
+		Preload("SharedPost.Attachments", attachment.InOrder).
+		Scopes(visibility.VisibleTo(viewerID, "posts")).
+		Order("created_at desc").
+		Where("user_id = ?", companyID).
+		Find(&posts).Error; err != nil {
 		return nil, err
 	}
 	return posts, nil
@@ -153,8 +247,12 @@ func (r *companyRepository) AddJobSkill(ctx context.Context, jobSkill entities.J
 	return nil
 }
 
+// UpdateJob saves the editable fields of the job. They are listed so a nil
+// deadline clears it; the status and its timestamps are left as they are.
 func (r *companyRepository) UpdateJob(ctx context.Context, job entities.Job) error {
-	if err := r.db.WithContext(ctx).Model(&job).Updates(&job).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&job).
+		Select("title", "description", "location", "location_type", "job_type", "experience_level", "salary_min", "salary_max", "deadline").
+		Updates(&job).Error; err != nil {
 		return err
 	}
 	return nil
@@ -166,3 +264,74 @@ func (r *companyRepository) DeleteJobSkillsByJobID(ctx context.Context, jobID uu
 	}
 	return nil
 }
+
+func (r *companyRepository) GetCompanyByID(ctx context.Context, companyID uuid.UUID) (entities.Companies, error) {
+	var company entities.Companies
+	if err := r.db.WithContext(ctx).Preload("User").First(&company, "id = ?", companyID).Error; err != nil {
+		return entities.Companies{}, err
+	}
+	return company, nil
+}
+
+func (r *companyRepository) FollowCompany(ctx context.Context, follower entities.CompanyFollower) error {
+	if err := r.db.WithContext(ctx).Create(&follower).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *companyRepository) UnfollowCompany(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("company_id = ? AND user_id = ?", companyID, userID).
+		Delete(&entities.CompanyFollower{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *companyRepository) IsFollowingCompany(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.CompanyFollower{}).
+		Where("company_id = ? AND user_id = ?", companyID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *companyRepository) CountFollowers(ctx context.Context, companyID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.CompanyFollower{}).
+		Where("company_id = ?", companyID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *companyRepository) GetFollowedCompanies(ctx context.Context, userID uuid.UUID) ([]entities.CompanyFollower, error) {
+	var followed []entities.CompanyFollower
+	if err := r.db.WithContext(ctx).
+		Preload("Company.User").
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&followed).Error; err != nil {
+		return nil, err
+	}
+	return followed, nil
+}
+
+func (r *companyRepository) GetFollowerIDs(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error) {
+	var followerIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&entities.CompanyFollower{}).
+		Where("company_id = ?", companyID).
+		Pluck("user_id", &followerIDs).Error; err != nil {
+		return nil, err
+	}
+	return followerIDs, nil
+}
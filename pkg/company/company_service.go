@@ -5,39 +5,73 @@ import (
 	"Go-Starter-Template/entities"
 	"Go-Starter-Template/internal/utils"
 	"Go-Starter-Template/internal/utils/storage"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/comment"
 	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"Go-Starter-Template/pkg/poll"
+	"Go-Starter-Template/pkg/reaction"
+	"Go-Starter-Template/pkg/share"
+	"Go-Starter-Template/pkg/tag"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type (
 	CompanyService interface {
-		GetProfile(ctx context.Context, slug string) (*domain.CompanyProfileResponse, error)
+		GetProfile(ctx context.Context, slug string, viewerID string) (*domain.CompanyProfileResponse, error)
 		AddJob(ctx context.Context, req domain.CompanyAddJobRequest, userID string) error
 		UpdateJob(ctx context.Context, req domain.CompanyUpdateJobRequest, userID string) error
+		OpenJob(ctx context.Context, jobID string, req domain.CompanyOpenJobRequest, userID string) error
+		PauseJob(ctx context.Context, jobID string, userID string) error
+		CloseJob(ctx context.Context, jobID string, userID string) error
+		ExpireJobs(ctx context.Context) error
 		UpdateProfile(ctx context.Context, req domain.CompanyUpdateProfileRequest, userID string) error
 		LoginCompany(ctx context.Context, req domain.CompanyLoginRequest) (*domain.CompanyLoginResponse, error)
 		RegisterCompany(ctx context.Context, req domain.CompanyRegisterRequest) error
-		GetListCompany(ctx context.Context) ([]domain.CompanyListResponse, error)
+		GetListCompany(ctx context.Context, pagination domain.PaginationRequest) ([]domain.CompanyListResponse, domain.PaginationResponse, error)
+		FollowCompany(ctx context.Context, companyID string, userID string) error
+		UnfollowCompany(ctx context.Context, companyID string, userID string) error
+		GetFollowedCompanies(ctx context.Context, userID string) ([]domain.FollowedCompanyResponse, error)
 	}
 
 	companyService struct {
-		companyRepository CompanyRepository
-		awsS3             storage.AwsS3
-		jwtService        jwtService.JWTService
+		companyRepository      CompanyRepository
+		notificationRepository notification.NotificationRepository
+		reactionRepository     reaction.ReactionRepository
+		commentRepository      comment.CommentRepository
+		shareRepository        share.ShareRepository
+		pollRepository         poll.PollRepository
+		awsS3                  storage.AwsS3
+		jwtService             jwtService.JWTService
 	}
 )
 
-func NewCompanyService(companyRepository CompanyRepository, awsS3 storage.AwsS3, jwtService jwtService.JWTService) CompanyService {
-	return &companyService{companyRepository: companyRepository, awsS3: awsS3, jwtService: jwtService}
+func NewCompanyService(companyRepository CompanyRepository, notificationRepository notification.NotificationRepository, reactionRepository reaction.ReactionRepository, commentRepository comment.CommentRepository, shareRepository share.ShareRepository, pollRepository poll.PollRepository, awsS3 storage.AwsS3, jwtService jwtService.JWTService) CompanyService {
+	return &companyService{companyRepository: companyRepository, notificationRepository: notificationRepository, reactionRepository: reactionRepository, commentRepository: commentRepository, shareRepository: shareRepository, pollRepository: pollRepository, awsS3: awsS3, jwtService: jwtService}
 }
 
-func (s *companyService) GetListCompany(ctx context.Context) ([]domain.CompanyListResponse, error) {
-	companies, err := s.companyRepository.GetListCompany(ctx)
+func (s *companyService) GetListCompany(ctx context.Context, pagination domain.PaginationRequest) ([]domain.CompanyListResponse, domain.PaginationResponse, error) {
+	companies, err := s.companyRepository.GetListCompany(ctx, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
 
 	if err != nil {
-		return nil, domain.ErrCompanyNotFound
+		return nil, domain.PaginationResponse{}, domain.ErrCompanyNotFound
+	}
+
+	companies, hasMore := utils.TrimPage(companies, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := companies[len(companies)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeKeyCursor(last.Name, last.ID), HasMore: true}
 	}
 
 	var companyListResponse []domain.CompanyListResponse
@@ -50,7 +84,11 @@ func (s *companyService) GetListCompany(ctx context.Context) ([]domain.CompanyLi
 
 	}
 
-	return companyListResponse, nil
+	if companyListResponse == nil {
+		companyListResponse = []domain.CompanyListResponse{}
+	}
+
+	return companyListResponse, meta, nil
 }
 
 func (s *companyService) LoginCompany(ctx context.Context, req domain.CompanyLoginRequest) (*domain.CompanyLoginResponse, error) {
@@ -68,6 +106,10 @@ func (s *companyService) LoginCompany(ctx context.Context, req domain.CompanyLog
 		return nil, domain.CredentialInvalid
 	}
 
+	if user.SuspendedUntil != nil && user.SuspendedUntil.After(time.Now()) {
+		return nil, domain.ErrAccountSuspended
+	}
+
 	token := s.jwtService.GenerateTokenUser(user.ID.String(), user.Role)
 
 	return &domain.CompanyLoginResponse{
@@ -124,30 +166,79 @@ func (s *companyService) RegisterCompany(ctx context.Context, req domain.Company
 	return nil
 }
 
-func (s *companyService) GetProfile(ctx context.Context, slug string) (*domain.CompanyProfileResponse, error) {
+func (s *companyService) GetProfile(ctx context.Context, slug string, viewerID string) (*domain.CompanyProfileResponse, error) {
 	company, err := s.companyRepository.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	companyJobs, err := s.companyRepository.GetJobsByCompanyID(ctx, company.ID)
+	followerCount, err := s.companyRepository.CountFollowers(ctx, company.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	companyPosts, err := s.companyRepository.GetPostsByCompanyID(ctx, company.UserID)
+	var isFollowing bool
+	parsedViewerID, err := uuid.Parse(viewerID)
+	if err == nil {
+		isFollowing, err = s.companyRepository.IsFollowingCompany(ctx, company.ID, parsedViewerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	companyJobs, err := s.companyRepository.GetJobsByCompanyID(ctx, company.ID, parsedViewerID == company.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	companyPosts, err := s.companyRepository.GetPostsByCompanyID(ctx, company.UserID, parsedViewerID)
 
 	if err != nil {
 		return nil, err
 	}
 
+	postIDs := make([]uuid.UUID, 0, len(companyPosts))
+	var pollIDs []uuid.UUID
+	for _, post := range companyPosts {
+		postIDs = append(postIDs, post.ID)
+		if post.Poll != nil {
+			pollIDs = append(pollIDs, post.Poll.ID)
+		}
+	}
+
+	reactions, err := s.reactionRepository.GetReactionSummaries(ctx, postIDs, parsedViewerID)
+
+	if err != nil {
+		return nil, domain.ErrGetReactions
+	}
+
+	commentCounts, err := s.commentRepository.CountComments(ctx, postIDs)
+
+	if err != nil {
+		return nil, domain.ErrGetComments
+	}
+
+	shareCounts, err := s.shareRepository.CountShares(ctx, postIDs)
+
+	if err != nil {
+		return nil, domain.ErrGetShares
+	}
+
+	polls, err := s.pollRepository.GetResults(ctx, pollIDs, parsedViewerID)
+
+	if err != nil {
+		return nil, domain.ErrGetPoll
+	}
+
 	companyInfoResponse := domain.CompanyInfoResponse{
-		ID:       company.ID.String(),
-		Name:     company.Name,
-		About:    company.About,
-		Industry: company.Industry,
-		Logo:     company.User.ProfilePicture,
-		Headline: company.User.Headline,
+		ID:            company.ID.String(),
+		Name:          company.Name,
+		About:         company.About,
+		Industry:      company.Industry,
+		Logo:          company.User.ProfilePicture,
+		Headline:      company.User.Headline,
+		FollowerCount: followerCount,
+		IsFollowing:   isFollowing,
 	}
 
 	var companyJobsResponse []domain.CompanyJobsResponse
@@ -180,6 +271,7 @@ func (s *companyService) GetProfile(ctx context.Context, slug string) (*domain.C
 			SalaryMin:       job.SalaryMin,
 			SalaryMax:       job.SalaryMax,
 			Status:          job.Status,
+			Deadline:        utils.ConvertOptionalTimestampToString(job.Deadline),
 			Description:     job.Description,
 			Skills:          companyJobSkillsResponse,
 			Posted:          utils.ConvertTimeToString(job.CreatedAt),
@@ -202,6 +294,15 @@ func (s *companyService) GetProfile(ctx context.Context, slug string) (*domain.C
 			Asset:          post.Asset,
 			Slug:           company.Slug,
 			Type:           post.User.Role,
+			Visibility:     post.Visibility,
+			EditedAt:       utils.ConvertOptionalTimeToString(post.EditedAt),
+			Attachments:    attachment.ToAttachmentResponses(post.Attachments),
+			Entities:       tag.ToPostEntityResponses(post),
+			SharedPost:     share.ToSharedPostResponse(post),
+			Poll:           poll.ToPollResponse(post, parsedViewerID, polls),
+			Reactions:      reactions[post.ID],
+			CommentCount:   commentCounts[post.ID],
+			ShareCount:     shareCounts[post.ID],
 		})
 	}
 
@@ -284,6 +385,22 @@ func (s *companyService) AddJob(ctx context.Context, req domain.CompanyAddJobReq
 		return domain.ErrCompanyNotFound
 	}
 
+	status := req.Status
+
+	if status == "" {
+		status = domain.JobStatusOpen
+	}
+
+	if status != domain.JobStatusDraft && status != domain.JobStatusOpen {
+		return domain.ErrInvalidJobStatus
+	}
+
+	deadline, err := parseDeadline(req.Deadline)
+
+	if err != nil {
+		return err
+	}
+
 	job := entities.Job{
 		ID:              uuid.New(),
 		CompanyID:       company.ID,
@@ -295,7 +412,13 @@ func (s *companyService) AddJob(ctx context.Context, req domain.CompanyAddJobReq
 		SalaryMin:       req.SalaryMin,
 		SalaryMax:       req.SalaryMax,
 		Description:     req.Description,
-		Status:          "active",
+		Status:          status,
+		Deadline:        deadline,
+	}
+
+	if status == domain.JobStatusOpen {
+		now := time.Now()
+		job.OpenedAt = &now
 	}
 
 	jobID := s.companyRepository.AddJob(ctx, job)
@@ -316,9 +439,143 @@ func (s *companyService) AddJob(ctx context.Context, req domain.CompanyAddJobReq
 		}
 	}
 
+	if status == domain.JobStatusDraft {
+		return nil
+	}
+
+	s.announceJob(ctx, company, job)
+
+	return nil
+}
+
+func (s *companyService) announceJob(ctx context.Context, company entities.Companies, job entities.Job) {
+	NotifyFollowers(ctx, s.companyRepository, s.notificationRepository, company, "New job at "+company.Name, company.Name+" is hiring: "+job.Title)
+}
+
+// parseDeadline reads an optional application deadline, which has to be in
+// the future.
+func parseDeadline(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return nil, domain.ErrInvalidJobDeadline
+	}
+
+	if !deadline.After(time.Now()) {
+		return nil, domain.ErrJobDeadlineInPast
+	}
+
+	deadline = deadline.Local()
+	return &deadline, nil
+}
+
+func (s *companyService) FollowCompany(ctx context.Context, companyID string, userID string) error {
+	parsedCompanyID, err := uuid.Parse(companyID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	if _, err := s.companyRepository.GetCompanyByID(ctx, parsedCompanyID); err != nil {
+		return domain.ErrCompanyNotFound
+	}
+
+	following, err := s.companyRepository.IsFollowingCompany(ctx, parsedCompanyID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrFollowCompany
+	}
+
+	if following {
+		return domain.ErrCompanyAlreadyFollowed
+	}
+
+	err = s.companyRepository.FollowCompany(ctx, entities.CompanyFollower{
+		CompanyID: parsedCompanyID,
+		UserID:    parsedUserID,
+	})
+
+	if err != nil {
+		return domain.ErrFollowCompany
+	}
+
+	return nil
+}
+
+func (s *companyService) UnfollowCompany(ctx context.Context, companyID string, userID string) error {
+	parsedCompanyID, err := uuid.Parse(companyID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	removed, err := s.companyRepository.UnfollowCompany(ctx, parsedCompanyID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrUnfollowCompany
+	}
+
+	if !removed {
+		return domain.ErrCompanyNotFollowed
+	}
+
 	return nil
 }
 
+func (s *companyService) GetFollowedCompanies(ctx context.Context, userID string) ([]domain.FollowedCompanyResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	followed, err := s.companyRepository.GetFollowedCompanies(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetFollowedCompanies
+	}
+
+	followedResponse := make([]domain.FollowedCompanyResponse, 0, len(followed))
+
+	for _, follow := range followed {
+		if follow.Company == nil {
+			continue
+		}
+
+		var logo string
+		if follow.Company.User != nil {
+			logo = follow.Company.User.ProfilePicture
+		}
+
+		followedResponse = append(followedResponse, domain.FollowedCompanyResponse{
+			ID:         follow.Company.ID.String(),
+			Name:       follow.Company.Name,
+			Slug:       follow.Company.Slug,
+			Industry:   follow.Company.Industry,
+			Logo:       logo,
+			FollowedAt: utils.ConvertTimeToString(follow.CreatedAt),
+		})
+	}
+
+	return followedResponse, nil
+}
+
 func (s *companyService) UpdateJob(ctx context.Context, req domain.CompanyUpdateJobRequest, userID string) error {
 
 	parsedUserID, err_parsed := uuid.Parse(userID)
@@ -333,8 +590,26 @@ func (s *companyService) UpdateJob(ctx context.Context, req domain.CompanyUpdate
 		return domain.ErrCompanyNotFound
 	}
 
+	parsedJobID, err := uuid.Parse(req.JobID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	if _, err := s.companyRepository.GetJobByID(ctx, parsedJobID, companyID.ID); err != nil {
+		return domain.ErrJobNotFound
+	}
+
+	deadline, err := parseDeadline(req.Deadline)
+
+	if err != nil {
+		return err
+	}
+
+	// The status is left alone; it only changes through the lifecycle
+	// endpoints.
 	job := entities.Job{
-		ID:              uuid.MustParse(req.JobID),
+		ID:              parsedJobID,
 		CompanyID:       companyID.ID,
 		Title:           req.Title,
 		Location:        req.Location,
@@ -344,10 +619,10 @@ func (s *companyService) UpdateJob(ctx context.Context, req domain.CompanyUpdate
 		SalaryMin:       req.SalaryMin,
 		SalaryMax:       req.SalaryMax,
 		Description:     req.Description,
-		Status:          "active",
+		Deadline:        deadline,
 	}
 
-	err := s.companyRepository.UpdateJob(ctx, job)
+	err = s.companyRepository.UpdateJob(ctx, job)
 
 	if err != nil {
 		return domain.ErrJobNotUpdated
@@ -373,3 +648,109 @@ func (s *companyService) UpdateJob(ctx context.Context, req domain.CompanyUpdate
 
 	return nil
 }
+
+func (s *companyService) OpenJob(ctx context.Context, jobID string, req domain.CompanyOpenJobRequest, userID string) error {
+	return s.changeJobStatus(ctx, jobID, userID, domain.JobStatusOpen, req.Deadline)
+}
+
+func (s *companyService) PauseJob(ctx context.Context, jobID string, userID string) error {
+	return s.changeJobStatus(ctx, jobID, userID, domain.JobStatusPaused, "")
+}
+
+func (s *companyService) CloseJob(ctx context.Context, jobID string, userID string) error {
+	return s.changeJobStatus(ctx, jobID, userID, domain.JobStatusClosed, "")
+}
+
+func statusTransitionAllowed(from string, to string) bool {
+	for _, status := range domain.JobStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// changeJobStatus moves one of the company's jobs through its lifecycle.
+// Opening a job restarts the time it may stay open without a deadline, and
+// a deadline that has already passed has to be replaced. Followers hear
+// about a job the first time it opens.
+func (s *companyService) changeJobStatus(ctx context.Context, jobID string, userID string, status string, deadline string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedJobID, err := uuid.Parse(jobID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	company, err := s.companyRepository.GetCompanyByUserID(ctx, parsedUserID)
+
+	if err != nil {
+		return domain.ErrCompanyNotFound
+	}
+
+	job, err := s.companyRepository.GetJobByID(ctx, parsedJobID, company.ID)
+
+	if err != nil {
+		return domain.ErrJobNotFound
+	}
+
+	if !statusTransitionAllowed(job.Status, status) {
+		return domain.ErrJobStatusTransition
+	}
+
+	from := job.Status
+	job.Status = status
+
+	if status == domain.JobStatusOpen {
+		newDeadline, err := parseDeadline(deadline)
+
+		if err != nil {
+			return err
+		}
+
+		if newDeadline != nil {
+			job.Deadline = newDeadline
+		}
+
+		now := time.Now()
+
+		if job.Deadline != nil && !job.Deadline.After(now) {
+			return domain.ErrJobDeadlineInPast
+		}
+
+		job.OpenedAt = &now
+	}
+
+	changed, err := s.companyRepository.ChangeJobStatus(ctx, job, from)
+
+	if err != nil {
+		return domain.ErrChangeJobStatus
+	}
+
+	if !changed {
+		return domain.ErrJobStatusTransition
+	}
+
+	if from == domain.JobStatusDraft && status == domain.JobStatusOpen {
+		s.announceJob(ctx, company, job)
+	}
+
+	return nil
+}
+
+// ExpireJobs expires the jobs past their deadline and the stale ones. It is
+// run periodically by the scheduler started in the app config.
+func (s *companyService) ExpireJobs(ctx context.Context) error {
+	now := time.Now()
+
+	if _, err := s.companyRepository.ExpireJobs(ctx, now, now.Add(-domain.JobMaxOpenDuration)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package company
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"log"
+)
+
+// NotifyFollowers tells the followers of a company about something it just
+// published. By then the job or post is stored, so a failure is only logged:
+// failing the request would make the client retry and publish it twice.
+func NotifyFollowers(ctx context.Context, companyRepository CompanyRepository, notificationRepository notification.NotificationRepository, company entities.Companies, title string, message string) {
+	followerIDs, err := companyRepository.GetFollowerIDs(ctx, company.ID)
+
+	if err != nil {
+		log.Printf("notify followers of company %s: %v", company.ID, err)
+		return
+	}
+
+	notifications := make([]entities.Notification, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		notifications = append(notifications, entities.Notification{
+			UserID:           followerID,
+			Title:            title,
+			Message:          message,
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeCompany,
+		})
+	}
+
+	if err := notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		log.Printf("notify followers of company %s: %v", company.ID, err)
+	}
+}
@@ -0,0 +1,159 @@
+package connection
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ConnectionRepository interface {
+		GetConnection(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (entities.UserConnection, error)
+		RequestConnection(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (entities.UserConnection, error)
+		UpdateConnectionStatus(ctx context.Context, connection entities.UserConnection, status string) error
+		GetConnections(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error)
+		GetIncomingRequests(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error)
+		GetOutgoingRequests(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error)
+	}
+
+	connectionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewConnectionRepository(db *gorm.DB) ConnectionRepository {
+	return &connectionRepository{db: db}
+}
+
+func (r *connectionRepository) GetConnection(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (entities.UserConnection, error) {
+	var connection entities.UserConnection
+	if err := r.db.WithContext(ctx).
+		Where("(user_id = ? AND connected_with_id = ?) OR (user_id = ? AND connected_with_id = ?)", userID, targetUserID, targetUserID, userID).
+		First(&connection).Error; err != nil {
+		return entities.UserConnection{}, err
+	}
+	return connection, nil
+}
+
+// RequestConnection sends a connection request from userID to targetUserID
+// and returns the pair's connection afterwards. Requests between the same two
+// users are serialized on a lock for the pair. A request that crosses a
+// pending one from the target accepts it instead, and any other earlier row
+// between the two is replaced so that the request belongs to its sender.
+func (r *connectionRepository) RequestConnection(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) (entities.UserConnection, error) {
+	connection := entities.UserConnection{
+		UserID:          userID,
+		ConnectedWithID: targetUserID,
+		Status:          domain.ConnectionStatusPending,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", pairKey(userID, targetUserID)).Error; err != nil {
+			return err
+		}
+
+		var existing entities.UserConnection
+		err := tx.Where("(user_id = ? AND connected_with_id = ?) OR (user_id = ? AND connected_with_id = ?)", userID, targetUserID, targetUserID, userID).
+			First(&existing).Error
+
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			switch {
+			case existing.Status == domain.ConnectionStatusAccepted:
+				return domain.ErrConnectionAlreadyExists
+			case existing.Status == domain.ConnectionStatusPending && existing.UserID == targetUserID:
+				if err := tx.Model(&existing).Update("status", domain.ConnectionStatusAccepted).Error; err != nil {
+					return err
+				}
+				connection = existing
+				return nil
+			case existing.Status == domain.ConnectionStatusPending:
+				return domain.ErrConnectionAlreadyPending
+			case !canTransition(existing.Status, domain.ConnectionStatusPending):
+				return domain.ErrConnectionInvalidStatus
+			}
+		}
+
+		if err := tx.Unscoped().
+			Where("(user_id = ? AND connected_with_id = ?) OR (user_id = ? AND connected_with_id = ?)", userID, targetUserID, targetUserID, userID).
+			Delete(&entities.UserConnection{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&connection).Error
+	})
+
+	if err != nil {
+		return entities.UserConnection{}, err
+	}
+
+	return connection, nil
+}
+
+// pairKey is the same for both directions of a pair of users.
+func pairKey(userID uuid.UUID, otherUserID uuid.UUID) string {
+	if userID.String() > otherUserID.String() {
+		userID, otherUserID = otherUserID, userID
+	}
+	return "user_connections:" + userID.String() + ":" + otherUserID.String()
+}
+
+func (r *connectionRepository) UpdateConnectionStatus(ctx context.Context, connection entities.UserConnection, status string) error {
+	res := r.db.WithContext(ctx).
+		Model(&entities.UserConnection{}).
+		Where("user_id = ? AND connected_with_id = ? AND status = ?", connection.UserID, connection.ConnectedWithID, connection.Status).
+		Update("status", status)
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *connectionRepository) GetConnections(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error) {
+	var connections []entities.UserConnection
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Connection").
+		Where("(user_id = ? OR connected_with_id = ?) AND status = ?", userID, userID, domain.ConnectionStatusAccepted).
+		Order("updated_at desc").
+		Find(&connections).Error; err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+func (r *connectionRepository) GetIncomingRequests(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error) {
+	var connections []entities.UserConnection
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("connected_with_id = ? AND status = ?", userID, domain.ConnectionStatusPending).
+		Order("created_at desc").
+		Find(&connections).Error; err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+func (r *connectionRepository) GetOutgoingRequests(ctx context.Context, userID uuid.UUID) ([]entities.UserConnection, error) {
+	var connections []entities.UserConnection
+	if err := r.db.WithContext(ctx).
+		Preload("Connection").
+		Where("user_id = ? AND status = ?", userID, domain.ConnectionStatusPending).
+		Order("created_at desc").
+		Find(&connections).Error; err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
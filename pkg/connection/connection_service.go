@@ -0,0 +1,377 @@
+package connection
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/block"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"Go-Starter-Template/pkg/user"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ConnectionService interface {
+		SendRequest(ctx context.Context, userID string, targetUserID string) error
+		AcceptRequest(ctx context.Context, userID string, requesterID string) error
+		RejectRequest(ctx context.Context, userID string, requesterID string) error
+		WithdrawRequest(ctx context.Context, userID string, targetUserID string) error
+		RemoveConnection(ctx context.Context, userID string, targetUserID string) error
+		GetConnections(ctx context.Context, userID string) ([]domain.ConnectionResponse, error)
+		GetIncomingRequests(ctx context.Context, userID string) ([]domain.ConnectionResponse, error)
+		GetOutgoingRequests(ctx context.Context, userID string) ([]domain.ConnectionResponse, error)
+		GetConnectionStatus(ctx context.Context, userID string, targetUserID string) (domain.ConnectionStatusResponse, error)
+	}
+
+	connectionService struct {
+		connectionRepository   ConnectionRepository
+		userRepository         user.UserRepository
+		notificationRepository notification.NotificationRepository
+		blockRepository        block.BlockRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewConnectionService(connectionRepository ConnectionRepository, userRepository user.UserRepository, notificationRepository notification.NotificationRepository, blockRepository block.BlockRepository, jwtService jwtService.JWTService) ConnectionService {
+	return &connectionService{
+		connectionRepository:   connectionRepository,
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		blockRepository:        blockRepository,
+		jwtService:             jwtService,
+	}
+}
+
+func canTransition(from string, to string) bool {
+	for _, status := range domain.ConnectionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func parseUserPair(userID string, targetUserID string) (uuid.UUID, uuid.UUID, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrParseUUID
+	}
+
+	parsedTargetUserID, err := uuid.Parse(targetUserID)
+
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrParseUUID
+	}
+
+	if parsedUserID == parsedTargetUserID {
+		return uuid.Nil, uuid.Nil, domain.ErrConnectionSelf
+	}
+
+	return parsedUserID, parsedTargetUserID, nil
+}
+
+func (s *connectionService) SendRequest(ctx context.Context, userID string, targetUserID string) error {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return err
+	}
+
+	sender, err := s.userRepository.GetUserByID(ctx, parsedUserID)
+
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	target, err := s.userRepository.GetUserByID(ctx, parsedTargetUserID)
+
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	if target.Role != domain.RoleUser {
+		return domain.ErrConnectionTargetNotAllowed
+	}
+
+	blocked, err := s.blockRepository.IsBlockedBetween(ctx, parsedUserID, parsedTargetUserID)
+
+	if err != nil {
+		return domain.ErrSendConnection
+	}
+
+	if blocked {
+		return domain.ErrUserBlocked
+	}
+
+	connection, err := s.connectionRepository.RequestConnection(ctx, parsedUserID, parsedTargetUserID)
+
+	if errors.Is(err, domain.ErrConnectionAlreadyExists) || errors.Is(err, domain.ErrConnectionAlreadyPending) || errors.Is(err, domain.ErrConnectionInvalidStatus) {
+		return err
+	}
+
+	if err != nil {
+		return domain.ErrSendConnection
+	}
+
+	// The target had already asked to connect, so this accepted their request.
+	if connection.Status == domain.ConnectionStatusAccepted {
+		notification.Notify(ctx, s.notificationRepository, entities.Notification{
+			UserID:           parsedTargetUserID,
+			Title:            "Connection Request Accepted",
+			Message:          sender.Name + " accepted your connection request",
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeConnection,
+		})
+
+		return nil
+	}
+
+	notification.Notify(ctx, s.notificationRepository, entities.Notification{
+		UserID:           parsedTargetUserID,
+		Title:            "New Connection Request",
+		Message:          sender.Name + " wants to connect with you",
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeConnection,
+	})
+
+	return nil
+}
+
+// respondToRequest moves a pending request addressed to userID into the given
+// status and returns the user who originally sent it.
+func (s *connectionService) respondToRequest(ctx context.Context, userID string, requesterID string, status string) (entities.User, entities.User, error) {
+	parsedUserID, parsedRequesterID, err := parseUserPair(userID, requesterID)
+
+	if err != nil {
+		return entities.User{}, entities.User{}, err
+	}
+
+	connection, err := s.connectionRepository.GetConnection(ctx, parsedUserID, parsedRequesterID)
+
+	if err != nil || connection.UserID != parsedRequesterID {
+		return entities.User{}, entities.User{}, domain.ErrConnectionNotFound
+	}
+
+	if !canTransition(connection.Status, status) {
+		return entities.User{}, entities.User{}, domain.ErrConnectionInvalidStatus
+	}
+
+	responder, err := s.userRepository.GetUserByID(ctx, parsedUserID)
+
+	if err != nil {
+		return entities.User{}, entities.User{}, domain.ErrUserNotFound
+	}
+
+	requester, err := s.userRepository.GetUserByID(ctx, parsedRequesterID)
+
+	if err != nil {
+		return entities.User{}, entities.User{}, domain.ErrUserNotFound
+	}
+
+	if err := s.connectionRepository.UpdateConnectionStatus(ctx, connection, status); err != nil {
+		return entities.User{}, entities.User{}, domain.ErrUpdateConnection
+	}
+
+	return responder, requester, nil
+}
+
+func (s *connectionService) AcceptRequest(ctx context.Context, userID string, requesterID string) error {
+	responder, requester, err := s.respondToRequest(ctx, userID, requesterID, domain.ConnectionStatusAccepted)
+
+	if err != nil {
+		return err
+	}
+
+	notification.Notify(ctx, s.notificationRepository, entities.Notification{
+		UserID:           requester.ID,
+		Title:            "Connection Request Accepted",
+		Message:          responder.Name + " accepted your connection request",
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeConnection,
+	})
+
+	return nil
+}
+
+func (s *connectionService) RejectRequest(ctx context.Context, userID string, requesterID string) error {
+	responder, requester, err := s.respondToRequest(ctx, userID, requesterID, domain.ConnectionStatusRejected)
+
+	if err != nil {
+		return err
+	}
+
+	notification.Notify(ctx, s.notificationRepository, entities.Notification{
+		UserID:           requester.ID,
+		Title:            "Connection Request Declined",
+		Message:          responder.Name + " declined your connection request",
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeConnection,
+	})
+
+	return nil
+}
+
+func (s *connectionService) WithdrawRequest(ctx context.Context, userID string, targetUserID string) error {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return err
+	}
+
+	connection, err := s.connectionRepository.GetConnection(ctx, parsedUserID, parsedTargetUserID)
+
+	if err != nil || connection.UserID != parsedUserID {
+		return domain.ErrConnectionNotFound
+	}
+
+	if !canTransition(connection.Status, domain.ConnectionStatusWithdrawn) {
+		return domain.ErrConnectionInvalidStatus
+	}
+
+	if err := s.connectionRepository.UpdateConnectionStatus(ctx, connection, domain.ConnectionStatusWithdrawn); err != nil {
+		return domain.ErrUpdateConnection
+	}
+
+	return nil
+}
+
+func (s *connectionService) RemoveConnection(ctx context.Context, userID string, targetUserID string) error {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return err
+	}
+
+	connection, err := s.connectionRepository.GetConnection(ctx, parsedUserID, parsedTargetUserID)
+
+	if err != nil {
+		return domain.ErrConnectionNotFound
+	}
+
+	if !canTransition(connection.Status, domain.ConnectionStatusRemoved) {
+		return domain.ErrConnectionInvalidStatus
+	}
+
+	if err := s.connectionRepository.UpdateConnectionStatus(ctx, connection, domain.ConnectionStatusRemoved); err != nil {
+		return domain.ErrUpdateConnection
+	}
+
+	return nil
+}
+
+func toConnectionResponse(other *entities.User, connection entities.UserConnection, since string) domain.ConnectionResponse {
+	return domain.ConnectionResponse{
+		UserID:         other.ID.String(),
+		Name:           other.Name,
+		Slug:           other.Slug,
+		ProfilePicture: other.ProfilePicture,
+		Headline:       other.CurrentTitle,
+		Status:         connection.Status,
+		Since:          since,
+	}
+}
+
+func (s *connectionService) GetConnections(ctx context.Context, userID string) ([]domain.ConnectionResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	connections, err := s.connectionRepository.GetConnections(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetConnections
+	}
+
+	connectionsResponse := make([]domain.ConnectionResponse, 0, len(connections))
+
+	for _, connection := range connections {
+		other := connection.Connection
+		if connection.ConnectedWithID == parsedUserID {
+			other = connection.User
+		}
+
+		connectionsResponse = append(connectionsResponse, toConnectionResponse(other, connection, utils.ConvertTimeToString(connection.UpdatedAt)))
+	}
+
+	return connectionsResponse, nil
+}
+
+func (s *connectionService) GetIncomingRequests(ctx context.Context, userID string) ([]domain.ConnectionResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	connections, err := s.connectionRepository.GetIncomingRequests(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetConnections
+	}
+
+	connectionsResponse := make([]domain.ConnectionResponse, 0, len(connections))
+
+	for _, connection := range connections {
+		connectionsResponse = append(connectionsResponse, toConnectionResponse(connection.User, connection, utils.ConvertTimeToString(connection.CreatedAt)))
+	}
+
+	return connectionsResponse, nil
+}
+
+func (s *connectionService) GetOutgoingRequests(ctx context.Context, userID string) ([]domain.ConnectionResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	connections, err := s.connectionRepository.GetOutgoingRequests(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetConnections
+	}
+
+	connectionsResponse := make([]domain.ConnectionResponse, 0, len(connections))
+
+	for _, connection := range connections {
+		connectionsResponse = append(connectionsResponse, toConnectionResponse(connection.Connection, connection, utils.ConvertTimeToString(connection.CreatedAt)))
+	}
+
+	return connectionsResponse, nil
+}
+
+func (s *connectionService) GetConnectionStatus(ctx context.Context, userID string, targetUserID string) (domain.ConnectionStatusResponse, error) {
+	parsedUserID, parsedTargetUserID, err := parseUserPair(userID, targetUserID)
+
+	if err != nil {
+		return domain.ConnectionStatusResponse{}, err
+	}
+
+	connection, err := s.connectionRepository.GetConnection(ctx, parsedUserID, parsedTargetUserID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ConnectionStatusResponse{}, nil
+	}
+
+	if err != nil {
+		return domain.ConnectionStatusResponse{}, domain.ErrGetConnections
+	}
+
+	direction := domain.ConnectionDirectionOutgoing
+	if connection.UserID == parsedTargetUserID {
+		direction = domain.ConnectionDirectionIncoming
+	}
+
+	return domain.ConnectionStatusResponse{
+		Status:    connection.Status,
+		Direction: direction,
+	}, nil
+}
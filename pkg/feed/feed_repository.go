@@ -0,0 +1,203 @@
+package feed
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/poll"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	FeedRepository interface {
+		GetNetworkPosts(ctx context.Context, viewerID uuid.UUID, after *FeedPosition, limit int) ([]FeedPost, error)
+		GetPopularPosts(ctx context.Context, viewerID uuid.UUID, after *FeedPosition, limit int) ([]FeedPost, error)
+		GetHashtagPosts(ctx context.Context, hashtag string, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]FeedPost, error)
+	}
+
+	feedRepository struct {
+		db *gorm.DB
+	}
+
+	// FeedPosition is the rank of a post in the feed, used as a keyset cursor.
+	FeedPosition struct {
+		Score float64
+		ID    uuid.UUID
+	}
+
+	FeedPost struct {
+		Post  entities.Post
+		Score float64
+	}
+
+	rankedPostRow struct {
+		ID    uuid.UUID
+		Score float64
+	}
+)
+
+func NewFeedRepository(db *gorm.DB) FeedRepository {
+	return &feedRepository{db: db}
+}
+
+// networkPostsSQL matches posts written by the viewer, by their accepted
+// connections or by the accounts of companies they follow, and posts tagged
+// with hashtags they follow.
+const networkPostsSQL = `(posts.user_id = @viewer
+	OR posts.user_id IN (
+		SELECT CASE WHEN user_id = @viewer THEN connected_with_id ELSE user_id END
+		FROM user_connections
+		WHERE (user_id = @viewer OR connected_with_id = @viewer) AND status = @accepted AND deleted_at IS NULL
+	)
+	OR posts.user_id IN (
+		SELECT co.user_id
+		FROM company_followers f
+		JOIN companies co ON co.id = f.company_id AND co.deleted_at IS NULL
+		WHERE f.user_id = @viewer AND f.deleted_at IS NULL
+	)
+	OR posts.id IN (
+		SELECT ph.post_id
+		FROM post_hashtags ph
+		JOIN hashtag_followers hf ON hf.hashtag_id = ph.hashtag_id AND hf.deleted_at IS NULL
+		WHERE hf.user_id = @viewer AND ph.deleted_at IS NULL
+	))`
+
+func networkArgs(viewerID uuid.UUID) map[string]interface{} {
+	return map[string]interface{}{
+		"viewer":   viewerID,
+		"accepted": domain.ConnectionStatusAccepted,
+	}
+}
+
+// rankedPosts orders posts by their stored feed score, a time-decayed
+// engagement where every FeedGravity seconds of age are worth one order of
+// magnitude of engagement. A post only moves when its engagement changes.
+func (r *feedRepository) rankedPosts(ctx context.Context, viewerID uuid.UUID, after *FeedPosition) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Select("posts.id, posts.feed_score AS score").
+		Scopes(block.ExcludeHidden(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts"))
+
+	if after != nil {
+		query = query.Where("(posts.feed_score, posts.id) < (?, ?)", after.Score, after.ID)
+	}
+
+	return query.Order("posts.feed_score DESC").Order("posts.id DESC")
+}
+
+// withPostDetails preloads what a post response needs. Shared originals the
+// viewer may not see are left out like deleted ones.
+func withPostDetails(viewerID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("User").
+			Preload("Mentions").
+			Preload("Attachments", attachment.InOrder).
+			Preload("Poll.Options", poll.OptionsInOrder).
+			Preload("SharedPost", block.ExcludeHidden(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+			Preload("SharedPost.User").
+			Preload("SharedPost.Attachments", attachment.InOrder)
+	}
+}
+
+// loadPosts fetches the ranked rows with their details, keeping the rank order.
+func (r *feedRepository) loadPosts(ctx context.Context, viewerID uuid.UUID, rows []rankedPostRow) ([]FeedPost, error) {
+	if len(rows) == 0 {
+		return []FeedPost{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	var posts []entities.Post
+	if err := r.db.WithContext(ctx).Scopes(withPostDetails(viewerID)).Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	postsByID := make(map[uuid.UUID]entities.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.ID] = post
+	}
+
+	feedPosts := make([]FeedPost, 0, len(rows))
+	for _, row := range rows {
+		if post, ok := postsByID[row.ID]; ok {
+			feedPosts = append(feedPosts, FeedPost{Post: post, Score: row.Score})
+		}
+	}
+
+	return feedPosts, nil
+}
+
+func (r *feedRepository) GetNetworkPosts(ctx context.Context, viewerID uuid.UUID, after *FeedPosition, limit int) ([]FeedPost, error) {
+	var rows []rankedPostRow
+
+	if err := r.rankedPosts(ctx, viewerID, after).
+		Where(networkPostsSQL, networkArgs(viewerID)).
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return r.loadPosts(ctx, viewerID, rows)
+}
+
+// GetPopularPosts ranks every post outside the viewer's network.
+func (r *feedRepository) GetPopularPosts(ctx context.Context, viewerID uuid.UUID, after *FeedPosition, limit int) ([]FeedPost, error) {
+	var rows []rankedPostRow
+
+	query := r.rankedPosts(ctx, viewerID, after)
+
+	if viewerID != uuid.Nil {
+		query = query.Where("NOT "+networkPostsSQL, networkArgs(viewerID))
+	}
+
+	if err := query.Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return r.loadPosts(ctx, viewerID, rows)
+}
+
+// GetHashtagPosts lists the posts tagged with a hashtag, newest first.
+func (r *feedRepository) GetHashtagPosts(ctx context.Context, hashtag string, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]FeedPost, error) {
+	var posts []entities.Post
+
+	query := r.db.WithContext(ctx).
+		Scopes(withPostDetails(viewerID), block.ExcludeHidden(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		Where(`posts.id IN (
+			SELECT ph.post_id
+			FROM post_hashtags ph
+			JOIN hashtags h ON h.id = ph.hashtag_id
+			WHERE h.name = ? AND ph.deleted_at IS NULL
+		)`, hashtag)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(posts.created_at, posts.id) < (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("posts.created_at DESC").Order("posts.id DESC").Limit(pagination.Limit + 1).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	feedPosts := make([]FeedPost, 0, len(posts))
+	for _, post := range posts {
+		feedPosts = append(feedPosts, FeedPost{Post: post})
+	}
+
+	return feedPosts, nil
+}
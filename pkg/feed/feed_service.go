@@ -0,0 +1,254 @@
+package feed
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/analytics"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/comment"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/poll"
+	"Go-Starter-Template/pkg/reaction"
+	"Go-Starter-Template/pkg/share"
+	"Go-Starter-Template/pkg/tag"
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type (
+	FeedService interface {
+		GetFeed(ctx context.Context, viewerID string, pagination domain.PaginationRequest) ([]domain.PostResponse, domain.PaginationResponse, error)
+		GetHashtagFeed(ctx context.Context, hashtag string, viewerID string, pagination domain.PaginationRequest) ([]domain.PostResponse, domain.PaginationResponse, error)
+	}
+
+	feedService struct {
+		feedRepository     FeedRepository
+		reactionRepository reaction.ReactionRepository
+		commentRepository  comment.CommentRepository
+		shareRepository    share.ShareRepository
+		pollRepository     poll.PollRepository
+		impressionBuffer   *analytics.ImpressionBuffer
+		jwtService         jwtService.JWTService
+	}
+)
+
+func NewFeedService(feedRepository FeedRepository, reactionRepository reaction.ReactionRepository, commentRepository comment.CommentRepository, shareRepository share.ShareRepository, pollRepository poll.PollRepository, impressionBuffer *analytics.ImpressionBuffer, jwtService jwtService.JWTService) FeedService {
+	return &feedService{feedRepository: feedRepository, reactionRepository: reactionRepository, commentRepository: commentRepository, shareRepository: shareRepository, pollRepository: pollRepository, impressionBuffer: impressionBuffer, jwtService: jwtService}
+}
+
+func toPostResponse(post FeedPost, viewerID uuid.UUID, polls poll.Results, reactions domain.ReactionSummaryResponse, commentCount int64, shareCount int64) domain.PostResponse {
+	return domain.PostResponse{
+		ID:             post.Post.ID.String(),
+		Name:           post.Post.User.Name,
+		Headline:       post.Post.User.CurrentTitle,
+		ProfilePicture: post.Post.User.ProfilePicture,
+		Content:        post.Post.Content,
+		Asset:          post.Post.Asset,
+		CreatedAt:      utils.ConvertTimeToString(post.Post.CreatedAt),
+		Slug:           post.Post.User.Slug,
+		Type:           post.Post.User.Role,
+		Visibility:     post.Post.Visibility,
+		EditedAt:       utils.ConvertOptionalTimeToString(post.Post.EditedAt),
+		Attachments:    attachment.ToAttachmentResponses(post.Post.Attachments),
+		Entities:       tag.ToPostEntityResponses(post.Post),
+		SharedPost:     share.ToSharedPostResponse(post.Post),
+		Poll:           poll.ToPollResponse(post.Post, viewerID, polls),
+		Reactions:      reactions,
+		CommentCount:   commentCount,
+		ShareCount:     shareCount,
+	}
+}
+
+// GetHashtagFeed lists the posts tagged with a hashtag, newest first.
+func (s *feedService) GetHashtagFeed(ctx context.Context, hashtag string, viewerID string, pagination domain.PaginationRequest) ([]domain.PostResponse, domain.PaginationResponse, error) {
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	hashtag, ok := tag.NormalizeHashtag(hashtag)
+
+	if !ok {
+		return nil, domain.PaginationResponse{}, domain.ErrInvalidHashtag
+	}
+
+	posts, err := s.feedRepository.GetHashtagPosts(ctx, hashtag, parsedViewerID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	posts, hasMore := utils.TrimPage(posts, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := posts[len(posts)-1].Post
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	return s.toPostResponses(ctx, parsedViewerID, posts, meta)
+}
+
+// encodeFeedCursor records which segment of the feed the next page starts in
+// and, unless it starts at the top of that segment, the last post shown.
+func encodeFeedCursor(segment string, last *FeedPost) string {
+	if last == nil {
+		return utils.EncodeCursor(segment)
+	}
+	return utils.EncodeCursor(segment, strconv.FormatFloat(last.Score, 'g', -1, 64), last.Post.ID.String())
+}
+
+func decodeFeedCursor(cursor string) (string, *FeedPosition, error) {
+	values, err := utils.DecodeCursor(cursor)
+
+	if err != nil {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	segment := values[0]
+
+	if segment != domain.FeedSegmentNetwork && segment != domain.FeedSegmentPopular {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	if len(values) == 1 {
+		return segment, nil, nil
+	}
+
+	if len(values) != 3 {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	score, err := strconv.ParseFloat(values[1], 64)
+
+	if err != nil {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(values[2])
+
+	if err != nil {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	return segment, &FeedPosition{Score: score, ID: id}, nil
+}
+
+// GetFeed pages through the viewer's network first and, once that runs out,
+// continues with popular posts from outside it. Anonymous viewers only get the
+// popular posts.
+func (s *feedService) GetFeed(ctx context.Context, viewerID string, pagination domain.PaginationRequest) ([]domain.PostResponse, domain.PaginationResponse, error) {
+	parsedViewerID, err := uuid.Parse(viewerID)
+
+	if err != nil {
+		parsedViewerID = uuid.Nil
+	}
+
+	segment := domain.FeedSegmentNetwork
+	if parsedViewerID == uuid.Nil {
+		segment = domain.FeedSegmentPopular
+	}
+
+	var after *FeedPosition
+
+	if pagination.Cursor != "" {
+		segment, after, err = decodeFeedCursor(pagination.Cursor)
+
+		if err != nil || (segment == domain.FeedSegmentNetwork && parsedViewerID == uuid.Nil) {
+			return nil, domain.PaginationResponse{}, domain.ErrInvalidCursor
+		}
+	}
+
+	var posts []FeedPost
+	var meta domain.PaginationResponse
+
+	if segment == domain.FeedSegmentNetwork {
+		posts, err = s.feedRepository.GetNetworkPosts(ctx, parsedViewerID, after, pagination.Limit+1)
+
+		if err != nil {
+			return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+		}
+
+		var hasMore bool
+		if posts, hasMore = utils.TrimPage(posts, pagination.Limit); hasMore {
+			meta = domain.PaginationResponse{NextCursor: encodeFeedCursor(segment, &posts[len(posts)-1]), HasMore: true}
+			return s.toPostResponses(ctx, parsedViewerID, posts, meta)
+		}
+
+		after = nil
+	}
+
+	remaining := pagination.Limit - len(posts)
+
+	popular, err := s.feedRepository.GetPopularPosts(ctx, parsedViewerID, after, remaining+1)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	popular, hasMore := utils.TrimPage(popular, remaining)
+	posts = append(posts, popular...)
+
+	if hasMore {
+		var last *FeedPost
+		if len(popular) > 0 {
+			last = &popular[len(popular)-1]
+		}
+		meta = domain.PaginationResponse{NextCursor: encodeFeedCursor(domain.FeedSegmentPopular, last), HasMore: true}
+	}
+
+	return s.toPostResponses(ctx, parsedViewerID, posts, meta)
+}
+
+func (s *feedService) toPostResponses(ctx context.Context, viewerID uuid.UUID, posts []FeedPost, meta domain.PaginationResponse) ([]domain.PostResponse, domain.PaginationResponse, error) {
+	postIDs := make([]uuid.UUID, 0, len(posts))
+	var pollIDs, servedIDs []uuid.UUID
+	for _, post := range posts {
+		postIDs = append(postIDs, post.Post.ID)
+		if post.Post.Poll != nil {
+			pollIDs = append(pollIDs, post.Post.Poll.ID)
+		}
+		if post.Post.UserID != viewerID {
+			servedIDs = append(servedIDs, post.Post.ID)
+		}
+	}
+
+	reactions, err := s.reactionRepository.GetReactionSummaries(ctx, postIDs, viewerID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	commentCounts, err := s.commentRepository.CountComments(ctx, postIDs)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	shareCounts, err := s.shareRepository.CountShares(ctx, postIDs)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	polls, err := s.pollRepository.GetResults(ctx, pollIDs, viewerID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetFeed
+	}
+
+	postResponses := make([]domain.PostResponse, 0, len(posts))
+
+	for _, post := range posts {
+		postResponses = append(postResponses, toPostResponse(post, viewerID, polls, reactions[post.Post.ID], commentCounts[post.Post.ID], shareCounts[post.Post.ID]))
+	}
+
+	s.impressionBuffer.Add(servedIDs, viewerID)
+
+	return postResponses, meta, nil
+}
@@ -0,0 +1,64 @@
+package job
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Listed keeps the open jobs whose deadline has not passed yet, including
+// the ones the expiry scheduler has not got to.
+func Listed(db *gorm.DB) *gorm.DB {
+	return db.Where("jobs.status = ? AND (jobs.deadline IS NULL OR jobs.deadline > NOW())", domain.JobStatusOpen)
+}
+
+// MatchingSearch keeps the jobs matching the query and filters of a search.
+func MatchingSearch(filters domain.JobSearchRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if searchQuery := buildSearchQuery(filters.Query); searchQuery != "" {
+			db = db.Where("jobs.search_vector @@ to_tsquery('english', ?)", searchQuery)
+		}
+
+		if filters.JobType != "" {
+			db = db.Where("jobs.job_type = ?", filters.JobType)
+		}
+		if filters.ExperienceLevel != "" {
+			db = db.Where("jobs.experience_level = ?", filters.ExperienceLevel)
+		}
+		if filters.LocationType != "" {
+			db = db.Where("jobs.location_type = ?", filters.LocationType)
+		}
+
+		if filters.DatePosted != "" {
+			if filters.DatePosted == "Past 24 hours" {
+				db = db.Where("jobs.created_at >= NOW() - INTERVAL '1 day'")
+			} else if filters.DatePosted == "Past Week" {
+				db = db.Where("jobs.created_at >= NOW() - INTERVAL '1 week'")
+			} else if filters.DatePosted == "Past Month" {
+				db = db.Where("jobs.created_at >= NOW() - INTERVAL '1 month'")
+			}
+		}
+
+		if filters.MinSalary > 0 && filters.MaxSalary > 0 {
+			db = db.Where("jobs.salary_min >= ?", filters.MinSalary)
+			db = db.Where("jobs.salary_min <= ?", filters.MaxSalary)
+		}
+
+		return db
+	}
+}
+
+func AcceptsApplications(job entities.Job, now time.Time) bool {
+	return job.Status == domain.JobStatusOpen && (job.Deadline == nil || job.Deadline.After(now))
+}
+
+// CurrentStatus is the job's status, counting a job past its deadline as
+// expired before the scheduler marks it.
+func CurrentStatus(job entities.Job, now time.Time) string {
+	if job.Status == domain.JobStatusOpen && !AcceptsApplications(job, now) {
+		return domain.JobStatusExpired
+	}
+	return job.Status
+}
@@ -3,26 +3,53 @@ package job
 import (
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	JobRepository interface {
-		SearchJob(ctx context.Context, filters domain.JobSearchRequest) ([]entities.Job, error)
+		SearchJob(ctx context.Context, filters domain.JobSearchRequest, pagination domain.PaginationRequest) ([]JobSearchResult, error)
 		GetJobDetail(ctx context.Context, id string) (entities.Job, error)
 		ApplyJob(ctx context.Context, jobApplication entities.JobApplication) error
-		GetApplicants(ctx context.Context, jobID uuid.UUID) ([]entities.JobApplication, error)
+		GetApplicants(ctx context.Context, jobID uuid.UUID, pagination domain.PaginationRequest) ([]entities.JobApplication, error)
 		CheckCompanyIDFromJob(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) error
 		ChangeApplicationStatus(ctx context.Context, jobApplication entities.JobApplication) error
 		CheckCompanyIDFromApplication(ctx context.Context, jobApplicationID uuid.UUID, userID uuid.UUID) error
 		GetJobApplicationByID(ctx context.Context, jobApplicationID uuid.UUID) (entities.JobApplication, error)
+		IsJobSaved(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) (bool, error)
+		SaveJob(ctx context.Context, savedJob entities.SavedJob) error
+		UnsaveJob(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) (bool, error)
+		GetSavedJobs(ctx context.Context, userID uuid.UUID, pagination domain.PaginationRequest) ([]entities.SavedJob, error)
+		GetSavedJobIDs(ctx context.Context, userID uuid.UUID, jobIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+		GetDueDeadlineReminders(ctx context.Context, until time.Time, limit int) ([]entities.SavedJob, error)
+		MarkDeadlineReminded(ctx context.Context, savedJob entities.SavedJob, deadline time.Time) (bool, error)
 	}
 	jobRepository struct {
 		db *gorm.DB
 	}
+
+	// JobSearchResult is a job matched by a search. The snippets are only
+	// set when searching by query.
+	JobSearchResult struct {
+		Job                entities.Job
+		Rank               float64
+		TitleSnippet       string
+		DescriptionSnippet string
+	}
+
+	jobSearchRow struct {
+		ID                 uuid.UUID
+		Rank               float64
+		TitleSnippet       string
+		DescriptionSnippet string
+	}
 )
 
 func NewJobRepository(db *gorm.DB) JobRepository {
@@ -58,7 +85,7 @@ func (r *jobRepository) CheckCompanyIDFromJob(ctx context.Context, jobID uuid.UU
 
 func (r *jobRepository) GetJobDetail(ctx context.Context, id string) (entities.Job, error) {
 	var job entities.Job
-	err := r.db.WithContext(ctx).Preload("Company.User").Preload("Skills").Where("id = ?", id).First(&job).Error
+	err := r.db.WithContext(ctx).Preload("Company.User").Preload("Skills").Where("id = ? AND hidden_at IS NULL AND status <> ?", id, domain.JobStatusDraft).First(&job).Error
 
 	if err != nil {
 		return entities.Job{}, err
@@ -67,57 +94,136 @@ func (r *jobRepository) GetJobDetail(ctx context.Context, id string) (entities.J
 	return job, nil
 }
 
-func (r *jobRepository) SearchJob(ctx context.Context, filters domain.JobSearchRequest) ([]entities.Job, error) {
+// SearchJob lists the open jobs matching the filters. With a search query
+// the jobs are matched against their full-text search vector, ranked by
+// relevance and come with highlighted snippets.
+func (r *jobRepository) SearchJob(ctx context.Context, filters domain.JobSearchRequest, pagination domain.PaginationRequest) ([]JobSearchResult, error) {
+	var rows []jobSearchRow
 
-	var jobs []entities.Job
-	query := r.db.WithContext(ctx).Preload("Company.User").Preload("Skills").Model(&entities.Job{})
+	query := r.db.WithContext(ctx).Model(&entities.Job{}).Scopes(Listed, MatchingSearch(filters)).Where("jobs.hidden_at IS NULL")
+
+	rank := gorm.Expr("0::double precision")
 
-	if filters.Title != "" {
-		query = query.Where("title ILIKE ?", "%"+filters.Title+"%")
+	if searchQuery := buildSearchQuery(filters.Query); searchQuery != "" {
+		tsQuery := gorm.Expr("to_tsquery('english', ?)", searchQuery)
+		rank = gorm.Expr("ts_rank_cd(jobs.search_vector, ?)::double precision", tsQuery)
+
+		query = query.Select("jobs.id, ? AS rank, ts_headline('english', jobs.title, ?, ?) AS title_snippet, ts_headline('english', jobs.description, ?, ?) AS description_snippet",
+			rank, tsQuery, titleHeadlineOptions, tsQuery, descriptionHeadlineOptions)
+	} else {
+		query = query.Select("jobs.id, ? AS rank", rank)
 	}
 
-	if filters.JobType != "" {
-		query = query.Where("job_type LIKE ?", "%"+filters.JobType+"%")
+	if pagination.Cursor != "" {
+		after, err := jobSearchAfter(filters.SortBy, pagination.Cursor, rank)
+
+		if err != nil {
+			return []JobSearchResult{}, err
+		}
+
+		query = query.Where(after)
 	}
-	if filters.ExperienceLevel != "" {
-		query = query.Where("experience_level LIKE ?", "%"+filters.ExperienceLevel+"%")
+
+	switch filters.SortBy {
+	case domain.JobSortRelevance:
+		query = query.Order("rank DESC").Order("jobs.id DESC")
+	case domain.JobSortSalaryHigh:
+		query = query.Order("jobs.salary_max DESC").Order("jobs.id DESC")
+	case domain.JobSortSalaryLow:
+		query = query.Order("jobs.salary_min ASC").Order("jobs.id ASC")
+	default:
+		query = query.Order("jobs.created_at DESC").Order("jobs.id DESC")
 	}
-	if filters.LocationType != "" {
-		query = query.Where("location_type LIKE ?", "%"+filters.LocationType+"%")
+
+	if err := query.Limit(pagination.Limit + 1).Scan(&rows).Error; err != nil {
+		return []JobSearchResult{}, err
 	}
 
-	if filters.DatePosted != "" {
-		if filters.DatePosted == "Past 24 hours" {
-			query = query.Where("created_at >= NOW() - INTERVAL '1 day'")
-		} else if filters.DatePosted == "Past Week" {
-			query = query.Where("created_at >= NOW() - INTERVAL '1 week'")
-		} else if filters.DatePosted == "Past Month" {
-			query = query.Where("created_at >= NOW() - INTERVAL '1 month'")
-		}
+	return r.loadSearchResults(ctx, rows)
+}
+
+// loadSearchResults fetches the matched jobs with their details, keeping the
+// order of the search.
+func (r *jobRepository) loadSearchResults(ctx context.Context, rows []jobSearchRow) ([]JobSearchResult, error) {
+	if len(rows) == 0 {
+		return []JobSearchResult{}, nil
 	}
 
-	if filters.SortBy != "" {
-		if filters.SortBy == "recent" {
-			query = query.Order("created_at DESC")
-		} else if filters.SortBy == "salary-high" {
-			query = query.Order("salary_max DESC")
-		} else if filters.SortBy == "salary-low" {
-			query = query.Order("salary_min ASC")
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	var jobs []entities.Job
+	if err := r.db.WithContext(ctx).Preload("Company.User").Preload("Skills").Where("id IN ?", ids).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	jobsByID := make(map[uuid.UUID]entities.Job, len(jobs))
+	for _, job := range jobs {
+		jobsByID[job.ID] = job
+	}
+
+	results := make([]JobSearchResult, 0, len(rows))
+	for _, row := range rows {
+		if job, ok := jobsByID[row.ID]; ok {
+			results = append(results, JobSearchResult{
+				Job:                job,
+				Rank:               row.Rank,
+				TitleSnippet:       row.TitleSnippet,
+				DescriptionSnippet: row.DescriptionSnippet,
+			})
 		}
 	}
 
-	if filters.MinSalary > 0 && filters.MaxSalary > 0 {
-		query = query.Where("salary_min >= ?", filters.MinSalary)
-		query = query.Where("salary_min <= ?", filters.MaxSalary)
+	return results, nil
+}
+
+// jobSearchAfter turns a search cursor into the condition that skips every job
+// up to and including the last one of the previous page. A cursor is only
+// valid for the sort order it was issued for.
+func jobSearchAfter(sortBy string, cursor string, rank clause.Expr) (clause.Expr, error) {
+	values, err := utils.DecodeCursor(cursor)
+
+	if err != nil || len(values) != 3 || values[0] != sortBy {
+		return clause.Expr{}, domain.ErrInvalidCursor
 	}
 
-	err := query.Find(&jobs).Error
+	id, err := uuid.Parse(values[2])
 
 	if err != nil {
-		return []entities.Job{}, err
+		return clause.Expr{}, domain.ErrInvalidCursor
 	}
 
-	return jobs, nil
+	switch sortBy {
+	case domain.JobSortRelevance:
+		lastRank, err := strconv.ParseFloat(values[1], 64)
+
+		if err != nil {
+			return clause.Expr{}, domain.ErrInvalidCursor
+		}
+
+		return gorm.Expr("(?, jobs.id) < (?, ?)", rank, lastRank, id), nil
+	case domain.JobSortSalaryHigh, domain.JobSortSalaryLow:
+		salary, err := strconv.Atoi(values[1])
+
+		if err != nil {
+			return clause.Expr{}, domain.ErrInvalidCursor
+		}
+
+		if sortBy == domain.JobSortSalaryHigh {
+			return gorm.Expr("(jobs.salary_max, jobs.id) < (?, ?)", salary, id), nil
+		}
+		return gorm.Expr("(jobs.salary_min, jobs.id) > (?, ?)", salary, id), nil
+	default:
+		createdAt, err := time.Parse(time.RFC3339Nano, values[1])
+
+		if err != nil {
+			return clause.Expr{}, domain.ErrInvalidCursor
+		}
+
+		return gorm.Expr("(jobs.created_at, jobs.id) < (?, ?)", createdAt, id), nil
+	}
 }
 
 func (r *jobRepository) ApplyJob(ctx context.Context, jobApplication entities.JobApplication) error {
@@ -129,9 +235,22 @@ func (r *jobRepository) ApplyJob(ctx context.Context, jobApplication entities.Jo
 	return nil
 }
 
-func (r *jobRepository) GetApplicants(ctx context.Context, jobID uuid.UUID) ([]entities.JobApplication, error) {
+func (r *jobRepository) GetApplicants(ctx context.Context, jobID uuid.UUID, pagination domain.PaginationRequest) ([]entities.JobApplication, error) {
 	var applicants []entities.JobApplication
-	err := r.db.WithContext(ctx).Preload("User").Where("job_id = ?", jobID).Find(&applicants).Error
+
+	query := r.db.WithContext(ctx).Preload("User").Where("job_id = ?", jobID)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return []entities.JobApplication{}, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&applicants).Error
 
 	if err != nil {
 		return []entities.JobApplication{}, err
@@ -194,3 +313,120 @@ func (r *jobRepository) CheckCompanyIDFromApplication(ctx context.Context, jobAp
 
 	return nil
 }
+
+func (r *jobRepository) IsJobSaved(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.SavedJob{}).
+		Where("job_id = ? AND user_id = ?", jobID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *jobRepository) SaveJob(ctx context.Context, savedJob entities.SavedJob) error {
+	if err := r.db.WithContext(ctx).Create(&savedJob).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *jobRepository) UnsaveJob(ctx context.Context, jobID uuid.UUID, userID uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("job_id = ? AND user_id = ?", jobID, userID).
+		Delete(&entities.SavedJob{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// GetSavedJobs lists the jobs the user saved, most recently saved first.
+// Jobs that were removed or hidden by moderation are left out.
+func (r *jobRepository) GetSavedJobs(ctx context.Context, userID uuid.UUID, pagination domain.PaginationRequest) ([]entities.SavedJob, error) {
+	var savedJobs []entities.SavedJob
+
+	query := r.db.WithContext(ctx).Preload("Job.Company.User").
+		Joins("JOIN jobs ON jobs.id = saved_jobs.job_id AND jobs.hidden_at IS NULL AND jobs.deleted_at IS NULL").
+		Where("saved_jobs.user_id = ?", userID)
+
+	if pagination.Cursor != "" {
+		savedAt, jobID, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return []entities.SavedJob{}, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(saved_jobs.created_at, saved_jobs.job_id) < (?, ?)", savedAt, jobID)
+	}
+
+	err := query.Order("saved_jobs.created_at DESC").Order("saved_jobs.job_id DESC").Limit(pagination.Limit + 1).Find(&savedJobs).Error
+
+	if err != nil {
+		return []entities.SavedJob{}, err
+	}
+
+	return savedJobs, nil
+}
+
+// GetSavedJobIDs tells which of the jobs the user saved.
+func (r *jobRepository) GetSavedJobIDs(ctx context.Context, userID uuid.UUID, jobIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	saved := make(map[uuid.UUID]bool)
+
+	if userID == uuid.Nil || len(jobIDs) == 0 {
+		return saved, nil
+	}
+
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&entities.SavedJob{}).
+		Where("user_id = ? AND job_id IN ?", userID, jobIDs).
+		Pluck("job_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		saved[id] = true
+	}
+
+	return saved, nil
+}
+
+// GetDueDeadlineReminders lists the saved jobs whose deadline falls before
+// until and whose users were not reminded of it and did not apply yet.
+func (r *jobRepository) GetDueDeadlineReminders(ctx context.Context, until time.Time, limit int) ([]entities.SavedJob, error) {
+	var savedJobs []entities.SavedJob
+
+	err := r.db.WithContext(ctx).Preload("Job.Company").
+		Joins("JOIN jobs ON jobs.id = saved_jobs.job_id AND jobs.hidden_at IS NULL AND jobs.deleted_at IS NULL").
+		Joins("JOIN companies ON companies.id = jobs.company_id AND companies.deleted_at IS NULL").
+		Scopes(Listed).
+		Where("jobs.deadline <= ?", until).
+		Where("saved_jobs.reminded_deadline IS NULL OR saved_jobs.reminded_deadline <> jobs.deadline").
+		Where("NOT EXISTS (SELECT 1 FROM job_applications WHERE job_applications.job_id = saved_jobs.job_id AND job_applications.user_id = saved_jobs.user_id AND job_applications.deleted_at IS NULL)").
+		Order("jobs.deadline").
+		Limit(limit).
+		Find(&savedJobs).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return savedJobs, nil
+}
+
+// MarkDeadlineReminded records that the user was reminded of the deadline.
+// It only succeeds for the first caller, so nobody is reminded twice.
+func (r *jobRepository) MarkDeadlineReminded(ctx context.Context, savedJob entities.SavedJob, deadline time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&entities.SavedJob{}).
+		Where("user_id = ? AND job_id = ?", savedJob.UserID, savedJob.JobID).
+		Where("reminded_deadline IS NULL OR reminded_deadline <> ?", deadline).
+		Update("reminded_deadline", deadline)
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,68 @@
+package job
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Matches in search snippets are wrapped in private use characters, so they
+// survive escaping the job text and can be turned into <mark> tags after.
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+var (
+	titleHeadlineOptions       = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", HighlightAll=true`
+	descriptionHeadlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=" ... "`
+
+	searchWord = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+	highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+)
+
+// buildSearchQuery turns what a candidate typed into a to_tsquery expression
+// matching every term. Quoted text is matched as a phrase and a trailing *
+// matches words starting with the term. Anything but letters and digits is
+// dropped, so the result is always valid tsquery syntax.
+func buildSearchQuery(input string) string {
+	var terms []string
+
+	for i, part := range strings.Split(input, `"`) {
+		if i%2 == 1 {
+			if term := searchPhrase(searchWord.FindAllString(part, -1), false); term != "" {
+				terms = append(terms, term)
+			}
+			continue
+		}
+
+		for _, field := range strings.Fields(part) {
+			if term := searchPhrase(searchWord.FindAllString(field, -1), strings.HasSuffix(field, "*")); term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func searchPhrase(words []string, prefix bool) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	if prefix {
+		words[len(words)-1] += ":*"
+	}
+
+	if len(words) == 1 {
+		return words[0]
+	}
+	return "(" + strings.Join(words, " <-> ") + ")"
+}
+
+// renderHighlight escapes a snippet and marks up its matches.
+func renderHighlight(snippet string) string {
+	return highlightReplacer.Replace(html.EscapeString(snippet))
+}
@@ -8,17 +8,24 @@ import (
 	jwtService "Go-Starter-Template/pkg/jwt"
 	"Go-Starter-Template/pkg/notification"
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type (
 	JobService interface {
-		SearchJob(ctx context.Context, jobFilters domain.JobSearchRequest) ([]domain.JobSearchResponse, error)
-		GetJobDetail(ctx context.Context, id string) (domain.JobDetailResponse, error)
+		SearchJob(ctx context.Context, jobFilters domain.JobSearchRequest, viewerID string, pagination domain.PaginationRequest) ([]domain.JobSearchResponse, domain.PaginationResponse, error)
+		GetJobDetail(ctx context.Context, id string, viewerID string) (domain.JobDetailResponse, error)
 		ApplyJob(ctx context.Context, req domain.JobApplyRequest, userID string) error
-		GetApplicants(ctx context.Context, jobID string, userID string) ([]domain.JobApplicantResponse, error)
+		GetApplicants(ctx context.Context, jobID string, userID string, pagination domain.PaginationRequest) ([]domain.JobApplicantResponse, domain.PaginationResponse, error)
 		ChangeApplicationStatus(ctx context.Context, req domain.JobChangeApplicationStatusRequest, userID string) error
+		SaveJob(ctx context.Context, jobID string, userID string) error
+		UnsaveJob(ctx context.Context, jobID string, userID string) error
+		GetSavedJobs(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]domain.SavedJobResponse, domain.PaginationResponse, error)
+		SendDeadlineReminders(ctx context.Context) error
 	}
 
 	jobService struct {
@@ -33,9 +40,21 @@ func NewJobService(jobRepository JobRepository, notificationRepository notificat
 	return &jobService{jobRepository: jobRepository, notificationRepository: notificationRepository, awsS3: awsS3, jwtService: jwtService}
 }
 
-func (s *jobService) GetJobDetail(ctx context.Context, id string) (domain.JobDetailResponse, error) {
+func (s *jobService) GetJobDetail(ctx context.Context, id string, viewerID string) (domain.JobDetailResponse, error) {
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
 	res, err := s.jobRepository.GetJobDetail(ctx, id)
 
+	if err != nil {
+		return domain.JobDetailResponse{}, err
+	}
+
+	saved, err := s.jobRepository.GetSavedJobIDs(ctx, parsedViewerID, []uuid.UUID{res.ID})
+
+	if err != nil {
+		return domain.JobDetailResponse{}, err
+	}
+
 	var jobResult domain.JobDetailResponse
 
 	var jobSkills []string
@@ -75,27 +94,60 @@ func (s *jobService) GetJobDetail(ctx context.Context, id string) (domain.JobDet
 		SalaryMax:       res.SalaryMax,
 		Description:     res.Description,
 		Status:          res.Status,
+		Deadline:        utils.ConvertOptionalTimestampToString(res.Deadline),
 		Posted:          utils.ConvertTimeToString(res.CreatedAt),
 		Skills:          jobSkills,
+		IsSaved:         saved[res.ID],
 	}
 
+	return jobResult, nil
+}
+
+func (s *jobService) SearchJob(ctx context.Context, jobFilters domain.JobSearchRequest, viewerID string, pagination domain.PaginationRequest) ([]domain.JobSearchResponse, domain.PaginationResponse, error) {
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	hasQuery := buildSearchQuery(jobFilters.Query) != ""
+
+	switch jobFilters.SortBy {
+	case domain.JobSortSalaryHigh, domain.JobSortSalaryLow, domain.JobSortRecent:
+	default:
+		if hasQuery {
+			jobFilters.SortBy = domain.JobSortRelevance
+		} else {
+			jobFilters.SortBy = domain.JobSortRecent
+		}
+	}
+
+	res, err := s.jobRepository.SearchJob(ctx, jobFilters, pagination)
+
 	if err != nil {
-		return domain.JobDetailResponse{}, err
+		return nil, domain.PaginationResponse{}, err
 	}
 
-	return jobResult, nil
-}
+	res, hasMore := utils.TrimPage(res, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		meta = domain.PaginationResponse{NextCursor: jobSearchCursor(jobFilters.SortBy, res[len(res)-1]), HasMore: true}
+	}
 
-func (s *jobService) SearchJob(ctx context.Context, jobFilters domain.JobSearchRequest) ([]domain.JobSearchResponse, error) {
-	res, err := s.jobRepository.SearchJob(ctx, jobFilters)
+	jobIDs := make([]uuid.UUID, 0, len(res))
+	for _, result := range res {
+		jobIDs = append(jobIDs, result.Job.ID)
+	}
+
+	saved, err := s.jobRepository.GetSavedJobIDs(ctx, parsedViewerID, jobIDs)
 
 	if err != nil {
-		return nil, err
+		return nil, domain.PaginationResponse{}, err
 	}
 
 	var jobSearchResponse []domain.JobSearchResponse
 
-	for _, job := range res {
+	for _, result := range res {
+		job := result.Job
+
 		var jobSkills []string
 
 		for _, skill := range job.Skills {
@@ -119,7 +171,7 @@ func (s *jobService) SearchJob(ctx context.Context, jobFilters domain.JobSearchR
 			jobSkills = []string{}
 		}
 
-		jobSearchResponse = append(jobSearchResponse, domain.JobSearchResponse{
+		jobResponse := domain.JobSearchResponse{
 			ID:              job.ID.String(),
 			CompanyName:     job.Company.Name,
 			CompanySlug:     job.Company.Slug,
@@ -133,16 +185,40 @@ func (s *jobService) SearchJob(ctx context.Context, jobFilters domain.JobSearchR
 			SalaryMax:       job.SalaryMax,
 			Description:     job.Description,
 			Status:          job.Status,
+			Deadline:        utils.ConvertOptionalTimestampToString(job.Deadline),
 			Posted:          utils.ConvertTimeToString(job.CreatedAt),
 			Skills:          jobSkills,
-		})
+			IsSaved:         saved[job.ID],
+		}
+
+		if hasQuery {
+			jobResponse.Highlights = &domain.JobHighlightResponse{
+				Title:       renderHighlight(result.TitleSnippet),
+				Description: renderHighlight(result.DescriptionSnippet),
+			}
+		}
+
+		jobSearchResponse = append(jobSearchResponse, jobResponse)
 	}
 
 	if jobSearchResponse == nil {
 		jobSearchResponse = []domain.JobSearchResponse{}
 	}
 
-	return jobSearchResponse, nil
+	return jobSearchResponse, meta, nil
+}
+
+func jobSearchCursor(sortBy string, result JobSearchResult) string {
+	switch sortBy {
+	case domain.JobSortRelevance:
+		return utils.EncodeCursor(sortBy, strconv.FormatFloat(result.Rank, 'g', -1, 64), result.Job.ID.String())
+	case domain.JobSortSalaryHigh:
+		return utils.EncodeCursor(sortBy, strconv.Itoa(result.Job.SalaryMax), result.Job.ID.String())
+	case domain.JobSortSalaryLow:
+		return utils.EncodeCursor(sortBy, strconv.Itoa(result.Job.SalaryMin), result.Job.ID.String())
+	default:
+		return utils.EncodeCursor(sortBy, result.Job.CreatedAt.Format(time.RFC3339Nano), result.Job.ID.String())
+	}
 }
 
 func (s *jobService) ApplyJob(ctx context.Context, req domain.JobApplyRequest, userID string) error {
@@ -158,6 +234,16 @@ func (s *jobService) ApplyJob(ctx context.Context, req domain.JobApplyRequest, u
 		return domain.ErrParseUUID
 	}
 
+	job, err := s.jobRepository.GetJobDetail(ctx, req.JobID)
+
+	if err != nil {
+		return domain.ErrJobNotFound
+	}
+
+	if !AcceptsApplications(job, time.Now()) {
+		return domain.ErrJobNotOpen
+	}
+
 	jobApplication := entities.JobApplication{
 		ID:     uuid.New(),
 		JobID:  parsedJobID,
@@ -186,29 +272,38 @@ func (s *jobService) ApplyJob(ctx context.Context, req domain.JobApplyRequest, u
 	return nil
 }
 
-func (s *jobService) GetApplicants(ctx context.Context, jobID string, userID string) ([]domain.JobApplicantResponse, error) {
+func (s *jobService) GetApplicants(ctx context.Context, jobID string, userID string, pagination domain.PaginationRequest) ([]domain.JobApplicantResponse, domain.PaginationResponse, error) {
 	parsedJobID, err := uuid.Parse(jobID)
 
 	if err != nil {
-		return nil, domain.ErrParseUUID
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
 	}
 
 	parsedUserID, err := uuid.Parse(userID)
 
 	if err != nil {
-		return nil, domain.ErrParseUUID
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
 	}
 
 	err = s.jobRepository.CheckCompanyIDFromJob(ctx, parsedJobID, parsedUserID)
 
 	if err != nil {
-		return nil, err
+		return nil, domain.PaginationResponse{}, err
 	}
 
-	res, err := s.jobRepository.GetApplicants(ctx, parsedJobID)
+	res, err := s.jobRepository.GetApplicants(ctx, parsedJobID, pagination)
 
 	if err != nil {
-		return nil, err
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	res, hasMore := utils.TrimPage(res, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := res[len(res)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
 	}
 
 	var jobApplicants []domain.JobApplicantResponse
@@ -231,7 +326,7 @@ func (s *jobService) GetApplicants(ctx context.Context, jobID string, userID str
 		jobApplicants = []domain.JobApplicantResponse{}
 	}
 
-	return jobApplicants, nil
+	return jobApplicants, meta, nil
 }
 
 func (s *jobService) ChangeApplicationStatus(ctx context.Context, req domain.JobChangeApplicationStatusRequest, userID string) error {
@@ -286,3 +381,167 @@ func (s *jobService) ChangeApplicationStatus(ctx context.Context, req domain.Job
 
 	return nil
 }
+
+func (s *jobService) SaveJob(ctx context.Context, jobID string, userID string) error {
+	parsedJobID, err := uuid.Parse(jobID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	if _, err := s.jobRepository.GetJobDetail(ctx, jobID); err != nil {
+		return domain.ErrJobNotFound
+	}
+
+	saved, err := s.jobRepository.IsJobSaved(ctx, parsedJobID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrSaveJob
+	}
+
+	if saved {
+		return domain.ErrJobAlreadySaved
+	}
+
+	err = s.jobRepository.SaveJob(ctx, entities.SavedJob{
+		UserID: parsedUserID,
+		JobID:  parsedJobID,
+	})
+
+	if err != nil {
+		return domain.ErrSaveJob
+	}
+
+	return nil
+}
+
+func (s *jobService) UnsaveJob(ctx context.Context, jobID string, userID string) error {
+	parsedJobID, err := uuid.Parse(jobID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	removed, err := s.jobRepository.UnsaveJob(ctx, parsedJobID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrUnsaveJob
+	}
+
+	if !removed {
+		return domain.ErrJobNotSaved
+	}
+
+	return nil
+}
+
+func (s *jobService) GetSavedJobs(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]domain.SavedJobResponse, domain.PaginationResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+	}
+
+	savedJobs, err := s.jobRepository.GetSavedJobs(ctx, parsedUserID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetSavedJobs
+	}
+
+	savedJobs, hasMore := utils.TrimPage(savedJobs, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := savedJobs[len(savedJobs)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.JobID), HasMore: true}
+	}
+
+	now := time.Now()
+
+	responses := make([]domain.SavedJobResponse, 0, len(savedJobs))
+
+	for _, savedJob := range savedJobs {
+		job := *savedJob.Job
+
+		responses = append(responses, domain.SavedJobResponse{
+			ID:              job.ID.String(),
+			CompanyName:     job.Company.Name,
+			CompanyLogo:     job.Company.User.ProfilePicture,
+			CompanySlug:     job.Company.Slug,
+			Title:           job.Title,
+			Location:        job.Location,
+			LocationType:    job.LocationType,
+			JobType:         job.JobType,
+			ExperienceLevel: job.ExperienceLevel,
+			SalaryMin:       job.SalaryMin,
+			SalaryMax:       job.SalaryMax,
+			Status:          CurrentStatus(job, now),
+			IsOpen:          AcceptsApplications(job, now),
+			Deadline:        utils.ConvertOptionalTimestampToString(job.Deadline),
+			Posted:          utils.ConvertTimeToString(job.CreatedAt),
+			SavedAt:         utils.ConvertTimeToString(savedJob.CreatedAt),
+		})
+	}
+
+	return responses, meta, nil
+}
+
+// SendDeadlineReminders reminds users of saved jobs that are about to stop
+// taking applications.
+func (s *jobService) SendDeadlineReminders(ctx context.Context) error {
+	savedJobs, err := s.jobRepository.GetDueDeadlineReminders(ctx, time.Now().Add(domain.JobDeadlineReminderWindow), domain.JobDeadlineReminderBatch)
+
+	if err != nil {
+		return err
+	}
+
+	for _, savedJob := range savedJobs {
+		job := savedJob.Job
+
+		if job == nil || job.Company == nil {
+			continue
+		}
+
+		marked, err := s.jobRepository.MarkDeadlineReminded(ctx, savedJob, *job.Deadline)
+
+		if err != nil {
+			return err
+		}
+
+		if !marked {
+			continue
+		}
+
+		err = s.notificationRepository.CreateNotification(ctx, entities.Notification{
+			UserID:           savedJob.UserID,
+			Title:            "Saved job closing soon",
+			Message:          "Applications for " + job.Title + " at " + job.Company.Name + " close on " + job.Deadline.Format("January 2, 2006 at 15:04"),
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeSavedJob,
+			ReferenceID:      &job.ID,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
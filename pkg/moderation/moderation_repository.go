@@ -0,0 +1,247 @@
+package moderation
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ModerationRepository interface {
+		GetReportableOwner(ctx context.Context, targetType string, targetID uuid.UUID, reporterID uuid.UUID) (uuid.UUID, error)
+		GetTargetOwner(ctx context.Context, targetType string, targetID uuid.UUID) (uuid.UUID, error)
+		GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error)
+		HasReported(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID) (bool, error)
+		CreateReport(ctx context.Context, report entities.Report) error
+		GetReportByID(ctx context.Context, reportID uuid.UUID) (entities.Report, error)
+		GetReports(ctx context.Context, status string, targetType string, pagination domain.PaginationRequest) ([]entities.Report, error)
+		ApplyAction(ctx context.Context, action entities.ModerationAction, suspendedUntil *time.Time) error
+		GetActions(ctx context.Context, targetID *uuid.UUID, pagination domain.PaginationRequest) ([]entities.ModerationAction, error)
+	}
+
+	moderationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewModerationRepository(db *gorm.DB) ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+// targetModel returns the model a report target is stored as.
+func targetModel(targetType string) interface{} {
+	switch targetType {
+	case domain.ReportTargetPost:
+		return &entities.Post{}
+	case domain.ReportTargetComment:
+		return &entities.PostComment{}
+	case domain.ReportTargetMessage:
+		return &entities.ChatMessage{}
+	case domain.ReportTargetJob:
+		return &entities.Job{}
+	default:
+		return &entities.User{}
+	}
+}
+
+func pluckOwner(query *gorm.DB, column string) (uuid.UUID, error) {
+	var ownerIDs []uuid.UUID
+	if err := query.Limit(1).Pluck(column, &ownerIDs).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(ownerIDs) == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+	return ownerIDs[0], nil
+}
+
+// GetReportableOwner returns the account behind a piece of content the
+// reporter can currently see. Messages can only be reported by the members
+// of their chat room.
+func (r *moderationRepository) GetReportableOwner(ctx context.Context, targetType string, targetID uuid.UUID, reporterID uuid.UUID) (uuid.UUID, error) {
+	db := r.db.WithContext(ctx)
+
+	switch targetType {
+	case domain.ReportTargetPost:
+		return pluckOwner(db.Model(&entities.Post{}).
+			Scopes(visibility.VisibleTo(reporterID, "posts")).
+			Where("posts.id = ?", targetID), "posts.user_id")
+	case domain.ReportTargetComment:
+		return pluckOwner(db.Model(&entities.PostComment{}).
+			Joins("JOIN posts ON posts.id = post_comments.post_id AND posts.deleted_at IS NULL").
+			Scopes(visibility.VisibleTo(reporterID, "posts")).
+			Where("post_comments.id = ? AND post_comments.hidden_at IS NULL", targetID), "post_comments.user_id")
+	case domain.ReportTargetMessage:
+		return pluckOwner(db.Model(&entities.ChatMessage{}).
+			Joins("JOIN chat_rooms ON chat_rooms.id = chat_messages.room_id").
+			Where("chat_messages.id = ? AND (chat_rooms.first_user_id = ? OR chat_rooms.second_user_id = ?)", targetID, reporterID, reporterID), "chat_messages.user_id")
+	case domain.ReportTargetJob:
+		return pluckOwner(db.Model(&entities.Job{}).
+			Joins("JOIN companies ON companies.id = jobs.company_id").
+			Where("jobs.id = ? AND jobs.hidden_at IS NULL AND jobs.status <> ?", targetID, domain.JobStatusDraft), "companies.user_id")
+	default:
+		return pluckOwner(db.Model(&entities.User{}).
+			Where("id = ? AND role <> ?", targetID, domain.RoleAdmin), "id")
+	}
+}
+
+// GetTargetOwner returns the account behind a report target, including
+// content that has already been removed.
+func (r *moderationRepository) GetTargetOwner(ctx context.Context, targetType string, targetID uuid.UUID) (uuid.UUID, error) {
+	db := r.db.WithContext(ctx).Unscoped()
+
+	switch targetType {
+	case domain.ReportTargetJob:
+		return pluckOwner(db.Model(&entities.Job{}).
+			Joins("JOIN companies ON companies.id = jobs.company_id").
+			Where("jobs.id = ?", targetID), "companies.user_id")
+	case domain.ReportTargetProfile:
+		return pluckOwner(db.Model(&entities.User{}).Where("id = ?", targetID), "id")
+	default:
+		return pluckOwner(db.Model(targetModel(targetType)).Where("id = ?", targetID), "user_id")
+	}
+}
+
+func (r *moderationRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+func (r *moderationRepository) HasReported(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Report{}).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ?", reporterID, targetType, targetID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *moderationRepository) CreateReport(ctx context.Context, report entities.Report) error {
+	if err := r.db.WithContext(ctx).Create(&report).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *moderationRepository) GetReportByID(ctx context.Context, reportID uuid.UUID) (entities.Report, error) {
+	var report entities.Report
+	if err := r.db.WithContext(ctx).First(&report, "id = ?", reportID).Error; err != nil {
+		return entities.Report{}, err
+	}
+	return report, nil
+}
+
+// GetReports lists reports oldest first, so the queue is worked through in
+// the order reports came in.
+func (r *moderationRepository) GetReports(ctx context.Context, status string, targetType string, pagination domain.PaginationRequest) ([]entities.Report, error) {
+	var reports []entities.Report
+
+	query := r.db.WithContext(ctx).Preload("Reporter").Where("status = ?", status)
+
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) > (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at ASC").Order("id ASC").Limit(pagination.Limit + 1).Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// ApplyAction carries out a moderation decision, records it and closes the
+// reports it settles, all in one transaction. Dismissing closes only the
+// report it was taken on; any other action settles every open report on the
+// same target.
+func (r *moderationRepository) ApplyAction(ctx context.Context, action entities.ModerationAction, suspendedUntil *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := applyToTarget(tx, action, suspendedUntil); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&action).Error; err != nil {
+			return err
+		}
+
+		reports := tx.Model(&entities.Report{}).Where("status = ?", domain.ReportStatusOpen)
+
+		if action.Action == domain.ModerationActionDismiss {
+			return reports.Where("id = ?", action.ReportID).Update("status", domain.ReportStatusDismissed).Error
+		}
+
+		return reports.
+			Where("target_type = ? AND target_id = ?", action.TargetType, action.TargetID).
+			Update("status", domain.ReportStatusResolved).Error
+	})
+}
+
+func applyToTarget(tx *gorm.DB, action entities.ModerationAction, suspendedUntil *time.Time) error {
+	switch action.Action {
+	case domain.ModerationActionHide:
+		return tx.Model(targetModel(action.TargetType)).Where("id = ?", action.TargetID).Update("hidden_at", time.Now()).Error
+	case domain.ModerationActionRestore:
+		return tx.Model(targetModel(action.TargetType)).Where("id = ?", action.TargetID).Update("hidden_at", nil).Error
+	case domain.ModerationActionRemove:
+		switch action.TargetType {
+		case domain.ReportTargetPost:
+			if err := tx.Where("post_id = ?", action.TargetID).Delete(&entities.PostComment{}).Error; err != nil {
+				return err
+			}
+		case domain.ReportTargetComment:
+			if err := tx.Where("parent_id = ?", action.TargetID).Delete(&entities.PostComment{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", action.TargetID).Delete(targetModel(action.TargetType)).Error
+	case domain.ModerationActionSuspend, domain.ModerationActionUnsuspend:
+		return tx.Model(&entities.User{}).Where("id = ?", action.TargetUserID).Update("suspended_until", suspendedUntil).Error
+	default:
+		return nil
+	}
+}
+
+func (r *moderationRepository) GetActions(ctx context.Context, targetID *uuid.UUID, pagination domain.PaginationRequest) ([]entities.ModerationAction, error) {
+	var actions []entities.ModerationAction
+
+	query := r.db.WithContext(ctx).Preload("Admin")
+
+	if targetID != nil {
+		query = query.Where("(target_id = ? OR target_user_id = ?)", *targetID, *targetID)
+	}
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&actions).Error; err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
@@ -0,0 +1,350 @@
+package moderation
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ModerationService interface {
+		ReportContent(ctx context.Context, req domain.ReportRequest, userID string) error
+		GetReports(ctx context.Context, status string, targetType string, pagination domain.PaginationRequest) ([]domain.ReportResponse, domain.PaginationResponse, error)
+		Moderate(ctx context.Context, req domain.ModerateRequest, adminID string) error
+		GetActions(ctx context.Context, targetID string, pagination domain.PaginationRequest) ([]domain.ModerationActionResponse, domain.PaginationResponse, error)
+	}
+
+	moderationService struct {
+		moderationRepository   ModerationRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewModerationService(moderationRepository ModerationRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) ModerationService {
+	return &moderationService{
+		moderationRepository:   moderationRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+// actionTargets lists the target types each content action applies to.
+// Actions on accounts apply to the owner of any target.
+var actionTargets = map[string][]string{
+	domain.ModerationActionHide:    {domain.ReportTargetPost, domain.ReportTargetComment, domain.ReportTargetJob},
+	domain.ModerationActionRestore: {domain.ReportTargetPost, domain.ReportTargetComment, domain.ReportTargetJob},
+	domain.ModerationActionRemove:  {domain.ReportTargetPost, domain.ReportTargetComment, domain.ReportTargetMessage, domain.ReportTargetJob},
+}
+
+func actionAllowed(action string, targetType string) bool {
+	targets, ok := actionTargets[action]
+
+	if !ok {
+		return true
+	}
+
+	for _, target := range targets {
+		if target == targetType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *moderationService) ReportContent(ctx context.Context, req domain.ReportRequest, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedTargetID, err := uuid.Parse(req.TargetID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	ownerID, err := s.moderationRepository.GetReportableOwner(ctx, req.TargetType, parsedTargetID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrReportTargetNotFound
+	}
+
+	if ownerID == parsedUserID {
+		return domain.ErrReportSelf
+	}
+
+	reported, err := s.moderationRepository.HasReported(ctx, parsedUserID, req.TargetType, parsedTargetID)
+
+	if err != nil {
+		return domain.ErrReportContent
+	}
+
+	if reported {
+		return domain.ErrAlreadyReported
+	}
+
+	err = s.moderationRepository.CreateReport(ctx, entities.Report{
+		ReporterID:   parsedUserID,
+		TargetType:   req.TargetType,
+		TargetID:     parsedTargetID,
+		TargetUserID: ownerID,
+		Reason:       req.Reason,
+		Details:      req.Details,
+		Status:       domain.ReportStatusOpen,
+	})
+
+	if err != nil {
+		return domain.ErrReportContent
+	}
+
+	return nil
+}
+
+func (s *moderationService) GetReports(ctx context.Context, status string, targetType string, pagination domain.PaginationRequest) ([]domain.ReportResponse, domain.PaginationResponse, error) {
+	if status == "" {
+		status = domain.ReportStatusOpen
+	}
+
+	reports, err := s.moderationRepository.GetReports(ctx, status, targetType, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetReports
+	}
+
+	reports, hasMore := utils.TrimPage(reports, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := reports[len(reports)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	reportsResponse := make([]domain.ReportResponse, 0, len(reports))
+
+	for _, report := range reports {
+		res := domain.ReportResponse{
+			ID:           report.ID.String(),
+			TargetType:   report.TargetType,
+			TargetID:     report.TargetID.String(),
+			TargetUserID: report.TargetUserID.String(),
+			Reason:       report.Reason,
+			Details:      report.Details,
+			Status:       report.Status,
+			ReporterID:   report.ReporterID.String(),
+			CreatedAt:    utils.ConvertTimeToString(report.CreatedAt),
+		}
+
+		if report.Reporter != nil {
+			res.ReporterName = report.Reporter.Name
+		}
+
+		reportsResponse = append(reportsResponse, res)
+	}
+
+	return reportsResponse, meta, nil
+}
+
+// Moderate applies an admin decision to reported content, or to any content
+// or account directly when no report is given, and lets the affected
+// account know.
+func (s *moderationService) Moderate(ctx context.Context, req domain.ModerateRequest, adminID string) error {
+	parsedAdminID, err := uuid.Parse(adminID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	action := entities.ModerationAction{
+		AdminID:    parsedAdminID,
+		TargetType: req.TargetType,
+		Action:     req.Action,
+		Note:       req.Note,
+	}
+
+	if req.ReportID != "" {
+		parsedReportID, err := uuid.Parse(req.ReportID)
+
+		if err != nil {
+			return domain.ErrParseUUID
+		}
+
+		report, err := s.moderationRepository.GetReportByID(ctx, parsedReportID)
+
+		if err != nil {
+			return domain.ErrReportNotFound
+		}
+
+		if req.Action == domain.ModerationActionDismiss && report.Status != domain.ReportStatusOpen {
+			return domain.ErrReportClosed
+		}
+
+		action.ReportID = &report.ID
+		action.TargetType = report.TargetType
+		action.TargetID = report.TargetID
+	} else {
+		if req.Action == domain.ModerationActionDismiss {
+			return domain.ErrDismissWithoutReport
+		}
+
+		if req.TargetType == "" || req.TargetID == "" {
+			return domain.ErrModerationTarget
+		}
+
+		action.TargetID, err = uuid.Parse(req.TargetID)
+
+		if err != nil {
+			return domain.ErrParseUUID
+		}
+	}
+
+	if !actionAllowed(req.Action, action.TargetType) {
+		return domain.ErrActionNotAllowed
+	}
+
+	action.TargetUserID, err = s.moderationRepository.GetTargetOwner(ctx, action.TargetType, action.TargetID)
+
+	if err != nil {
+		return domain.ErrReportTargetNotFound
+	}
+
+	owner, err := s.moderationRepository.GetUserByID(ctx, action.TargetUserID)
+
+	if err != nil {
+		return domain.ErrReportTargetNotFound
+	}
+
+	if owner.Role == domain.RoleAdmin && req.Action != domain.ModerationActionDismiss {
+		return domain.ErrActionNotAllowed
+	}
+
+	var suspendedUntil *time.Time
+
+	if req.Action == domain.ModerationActionSuspend {
+		if req.SuspendDays <= 0 {
+			return domain.ErrSuspendDays
+		}
+
+		until := time.Now().AddDate(0, 0, req.SuspendDays)
+		suspendedUntil = &until
+	}
+
+	err = s.moderationRepository.ApplyAction(ctx, action, suspendedUntil)
+
+	if err != nil {
+		return domain.ErrModerate
+	}
+
+	s.notifyOwner(ctx, action, suspendedUntil)
+
+	return nil
+}
+
+// notifyOwner tells the owner of the content about the decision. It is
+// already applied, so a failure is only logged.
+func (s *moderationService) notifyOwner(ctx context.Context, action entities.ModerationAction, suspendedUntil *time.Time) {
+	var title, message string
+
+	switch action.Action {
+	case domain.ModerationActionHide:
+		title, message = "Content Hidden", "Your "+action.TargetType+" was hidden for going against our community policies"
+	case domain.ModerationActionRestore:
+		title, message = "Content Restored", "Your "+action.TargetType+" is visible again after review"
+	case domain.ModerationActionRemove:
+		title, message = "Content Removed", "Your "+action.TargetType+" was removed for going against our community policies"
+	case domain.ModerationActionWarn:
+		title, message = "Account Warning", "You received a warning for going against our community policies"
+	case domain.ModerationActionSuspend:
+		title, message = "Account Suspended", "Your account is suspended until "+utils.ConvertTimeToString(*suspendedUntil)
+	case domain.ModerationActionUnsuspend:
+		title, message = "Suspension Lifted", "Your account is no longer suspended"
+	default:
+		return
+	}
+
+	if action.Note != "" {
+		message += ": " + action.Note
+	}
+
+	notification.Notify(ctx, s.notificationRepository, entities.Notification{
+		UserID:           action.TargetUserID,
+		Title:            title,
+		Message:          message,
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeModeration,
+		ReferenceID:      &action.TargetID,
+	})
+}
+
+func (s *moderationService) GetActions(ctx context.Context, targetID string, pagination domain.PaginationRequest) ([]domain.ModerationActionResponse, domain.PaginationResponse, error) {
+	var parsedTargetID *uuid.UUID
+
+	if targetID != "" {
+		id, err := uuid.Parse(targetID)
+
+		if err != nil {
+			return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+		}
+
+		parsedTargetID = &id
+	}
+
+	actions, err := s.moderationRepository.GetActions(ctx, parsedTargetID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetModerationActions
+	}
+
+	actions, hasMore := utils.TrimPage(actions, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := actions[len(actions)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	actionsResponse := make([]domain.ModerationActionResponse, 0, len(actions))
+
+	for _, action := range actions {
+		res := domain.ModerationActionResponse{
+			ID:           action.ID.String(),
+			AdminID:      action.AdminID.String(),
+			TargetType:   action.TargetType,
+			TargetID:     action.TargetID.String(),
+			TargetUserID: action.TargetUserID.String(),
+			Action:       action.Action,
+			Note:         action.Note,
+			CreatedAt:    utils.ConvertTimeToString(action.CreatedAt),
+		}
+
+		if action.ReportID != nil {
+			res.ReportID = action.ReportID.String()
+		}
+
+		if action.Admin != nil {
+			res.AdminName = action.Admin.Name
+		}
+
+		actionsResponse = append(actionsResponse, res)
+	}
+
+	return actionsResponse, meta, nil
+}
@@ -1,7 +1,9 @@
 package notification
 
 import (
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
 	"context"
 
 	"github.com/google/uuid"
@@ -10,11 +12,14 @@ import (
 
 type (
 	NotificationRepository interface {
-		GetNotification(ctx context.Context, userID string) ([]entities.Notification, error)
+		GetNotification(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]entities.Notification, error)
 		ReadNotification(ctx context.Context, notificationID string) error
 		GetNotificationByID(ctx context.Context, notificationID string) (entities.Notification, error)
 		CreateNotification(ctx context.Context, notification entities.Notification) error
+		CreateNotifications(ctx context.Context, notifications []entities.Notification) error
 		CheckIfSameTitleAndDateExist(ctx context.Context, userID uuid.UUID, title string) (bool, error)
+		GetUnreadNotificationByReference(ctx context.Context, userID uuid.UUID, notificationType string, referenceID uuid.UUID) (entities.Notification, error)
+		UpdateNotification(ctx context.Context, notification entities.Notification) error
 	}
 
 	notificationRepository struct {
@@ -36,10 +41,22 @@ func (r *notificationRepository) ReadNotification(ctx context.Context, notificat
 	return nil
 }
 
-func (r *notificationRepository) GetNotification(ctx context.Context, userID string) ([]entities.Notification, error) {
+func (r *notificationRepository) GetNotification(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]entities.Notification, error) {
 	var notifications []entities.Notification
 
-	err := r.db.Where("user_id = ?", userID).Find(&notifications).Error
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&notifications).Error
 
 	if err != nil {
 		return nil, err
@@ -70,6 +87,20 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, notific
 	return nil
 }
 
+func (r *notificationRepository) CreateNotifications(ctx context.Context, notifications []entities.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).CreateInBatches(&notifications, 100).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *notificationRepository) CheckIfSameTitleAndDateExist(ctx context.Context, userID uuid.UUID, title string) (bool, error) {
 	var existingNotification entities.Notification
 	if err := r.db.WithContext(ctx).Where("user_id = ? AND title = ? AND DATE(created_at) = DATE(?)", userID, title, gorm.Expr("NOW()")).First(&existingNotification).Error; err != nil {
@@ -80,3 +111,28 @@ func (r *notificationRepository) CheckIfSameTitleAndDateExist(ctx context.Contex
 	}
 	return true, nil
 }
+
+func (r *notificationRepository) GetUnreadNotificationByReference(ctx context.Context, userID uuid.UUID, notificationType string, referenceID uuid.UUID) (entities.Notification, error) {
+	var notification entities.Notification
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND notification_type = ? AND reference_id = ? AND is_read = ?", userID, notificationType, referenceID, false).
+		Order("created_at DESC").
+		First(&notification).Error
+
+	if err != nil {
+		return entities.Notification{}, err
+	}
+
+	return notification, nil
+}
+
+func (r *notificationRepository) UpdateNotification(ctx context.Context, notification entities.Notification) error {
+	err := r.db.WithContext(ctx).Save(&notification).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
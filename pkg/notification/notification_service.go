@@ -3,6 +3,7 @@ package notification
 import (
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
 	jwtService "Go-Starter-Template/pkg/jwt"
 	"context"
 
@@ -11,7 +12,7 @@ import (
 
 type (
 	NotificationService interface {
-		GetNotification(ctx context.Context, userID string) ([]entities.Notification, error)
+		GetNotification(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]entities.Notification, domain.PaginationResponse, error)
 		ReadNotification(ctx context.Context, notificationID string, userID string) error
 	}
 
@@ -25,14 +26,27 @@ func NewNotificationService(notificationRepository NotificationRepository, jwtSe
 	return &notificationService{notificationRepository: notificationRepository, jwtService: jwtService}
 }
 
-func (s *notificationService) GetNotification(ctx context.Context, userID string) ([]entities.Notification, error) {
-	res, err := s.notificationRepository.GetNotification(ctx, userID)
+func (s *notificationService) GetNotification(ctx context.Context, userID string, pagination domain.PaginationRequest) ([]entities.Notification, domain.PaginationResponse, error) {
+	res, err := s.notificationRepository.GetNotification(ctx, userID, pagination)
 
 	if err != nil {
-		return nil, err
+		return nil, domain.PaginationResponse{}, err
 	}
 
-	return res, nil
+	res, hasMore := utils.TrimPage(res, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := res[len(res)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	if res == nil {
+		res = []entities.Notification{}
+	}
+
+	return res, meta, nil
 }
 
 func (s *notificationService) ReadNotification(ctx context.Context, notificationID string, userID string) error {
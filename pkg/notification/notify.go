@@ -0,0 +1,16 @@
+package notification
+
+import (
+	"Go-Starter-Template/entities"
+	"context"
+	"log"
+)
+
+// Notify creates a notification about something that is already stored, so
+// a failure is only logged: failing the request would make the client retry
+// an action that went through.
+func Notify(ctx context.Context, notificationRepository NotificationRepository, notification entities.Notification) {
+	if err := notificationRepository.CreateNotification(ctx, notification); err != nil {
+		log.Printf("notify user %s (%s): %v", notification.UserID, notification.Title, err)
+	}
+}
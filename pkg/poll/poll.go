@@ -0,0 +1,70 @@
+package poll
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func percentage(count int64, total int64) int {
+	if total == 0 {
+		return 0
+	}
+	return int((count*100 + total/2) / total)
+}
+
+// ToPollResponse describes the poll of a post for the viewer. It returns nil
+// for posts without a poll.
+func ToPollResponse(post entities.Post, viewerID uuid.UUID, results Results) *domain.PollResponse {
+	if post.Poll == nil {
+		return nil
+	}
+
+	poll := post.Poll
+	votedOptionID, hasVoted := results.Votes[poll.ID]
+
+	res := &domain.PollResponse{
+		ID:       poll.ID.String(),
+		Question: poll.Question,
+		ClosesAt: poll.ClosesAt.Format(time.RFC3339),
+		IsClosed: !poll.ClosesAt.After(time.Now()),
+		HasVoted: hasVoted,
+		Options:  make([]domain.PollOptionResponse, 0, len(poll.Options)),
+	}
+
+	if hasVoted {
+		res.VotedOptionID = votedOptionID.String()
+	}
+
+	showResults := hasVoted || res.IsClosed || (viewerID != uuid.Nil && viewerID == post.UserID)
+
+	var total int64
+	for _, option := range poll.Options {
+		total += results.Counts[option.ID]
+	}
+
+	if showResults {
+		res.TotalVotes = &total
+	}
+
+	for _, option := range poll.Options {
+		optionRes := domain.PollOptionResponse{
+			ID:       option.ID.String(),
+			Text:     option.Text,
+			Position: option.Position,
+		}
+
+		if showResults {
+			votes := results.Counts[option.ID]
+			share := percentage(votes, total)
+			optionRes.Votes = &votes
+			optionRes.Percentage = &share
+		}
+
+		res.Options = append(res.Options, optionRes)
+	}
+
+	return res
+}
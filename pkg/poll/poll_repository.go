@@ -0,0 +1,169 @@
+package poll
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	PollRepository interface {
+		GetPollByID(ctx context.Context, pollID uuid.UUID, viewerID uuid.UUID) (entities.Poll, error)
+		GetResults(ctx context.Context, pollIDs []uuid.UUID, viewerID uuid.UUID) (Results, error)
+		HasVoted(ctx context.Context, pollID uuid.UUID, userID uuid.UUID) (bool, error)
+		CreateVote(ctx context.Context, vote entities.PollVote) error
+		GetClosedPolls(ctx context.Context, now time.Time, limit int) ([]entities.Poll, error)
+		NotifyResults(ctx context.Context, pollID uuid.UUID, notifiedAt time.Time, notification entities.Notification) (bool, error)
+	}
+
+	pollRepository struct {
+		db *gorm.DB
+	}
+
+	// Results holds the vote count of every option of a set of polls and
+	// the option the viewer picked in each poll they voted in.
+	Results struct {
+		Counts map[uuid.UUID]int64
+		Votes  map[uuid.UUID]uuid.UUID
+	}
+
+	optionCountRow struct {
+		OptionID uuid.UUID
+		Count    int64
+	}
+)
+
+func NewPollRepository(db *gorm.DB) PollRepository {
+	return &pollRepository{db: db}
+}
+
+func OptionsInOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+// GetPollByID loads a poll whose post the viewer is allowed to see.
+func (r *pollRepository) GetPollByID(ctx context.Context, pollID uuid.UUID, viewerID uuid.UUID) (entities.Poll, error) {
+	var poll entities.Poll
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = polls.post_id AND posts.deleted_at IS NULL").
+		Scopes(block.ExcludeBlocked(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		Preload("Post").
+		Preload("Options", OptionsInOrder).
+		First(&poll, "polls.id = ?", pollID).Error; err != nil {
+		return entities.Poll{}, err
+	}
+	return poll, nil
+}
+
+func (r *pollRepository) GetResults(ctx context.Context, pollIDs []uuid.UUID, viewerID uuid.UUID) (Results, error) {
+	results := Results{
+		Counts: make(map[uuid.UUID]int64),
+		Votes:  make(map[uuid.UUID]uuid.UUID),
+	}
+
+	if len(pollIDs) == 0 {
+		return results, nil
+	}
+
+	var rows []optionCountRow
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PollVote{}).
+		Select("option_id, COUNT(*) AS count").
+		Where("poll_id IN ?", pollIDs).
+		Group("option_id").
+		Scan(&rows).Error; err != nil {
+		return Results{}, err
+	}
+
+	for _, row := range rows {
+		results.Counts[row.OptionID] = row.Count
+	}
+
+	if viewerID == uuid.Nil {
+		return results, nil
+	}
+
+	var votes []entities.PollVote
+	if err := r.db.WithContext(ctx).
+		Where("poll_id IN ? AND user_id = ?", pollIDs, viewerID).
+		Find(&votes).Error; err != nil {
+		return Results{}, err
+	}
+
+	for _, vote := range votes {
+		results.Votes[vote.PollID] = vote.OptionID
+	}
+
+	return results, nil
+}
+
+func (r *pollRepository) HasVoted(ctx context.Context, pollID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PollVote{}).
+		Where("poll_id = ? AND user_id = ?", pollID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *pollRepository) CreateVote(ctx context.Context, vote entities.PollVote) error {
+	if err := r.db.WithContext(ctx).Create(&vote).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetClosedPolls lists the closed polls of published posts whose author has
+// not been sent the results yet.
+func (r *pollRepository) GetClosedPolls(ctx context.Context, now time.Time, limit int) ([]entities.Poll, error) {
+	var polls []entities.Poll
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = polls.post_id AND posts.deleted_at IS NULL").
+		Where("posts.status = ?", domain.PostStatusPublished).
+		Where("polls.closes_at <= ? AND polls.results_notified_at IS NULL", now).
+		Preload("Post").
+		Preload("Options", OptionsInOrder).
+		Order("polls.closes_at ASC").
+		Limit(limit).
+		Find(&polls).Error; err != nil {
+		return nil, err
+	}
+	return polls, nil
+}
+
+// NotifyResults marks the results of the poll as sent and stores the
+// notification carrying them in the same transaction. It reports false when
+// another run already sent them.
+func (r *pollRepository) NotifyResults(ctx context.Context, pollID uuid.UUID, notifiedAt time.Time, notification entities.Notification) (bool, error) {
+	var marked bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entities.Poll{}).
+			Where("id = ? AND results_notified_at IS NULL", pollID).
+			Update("results_notified_at", notifiedAt)
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		marked = true
+		return tx.Create(&notification).Error
+	})
+
+	if err != nil {
+		return false, err
+	}
+	return marked, nil
+}
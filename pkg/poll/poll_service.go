@@ -0,0 +1,184 @@
+package poll
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	PollService interface {
+		GetPoll(ctx context.Context, pollID string, viewerID string) (domain.PollResponse, error)
+		VotePoll(ctx context.Context, req domain.VotePollRequest, userID string) (domain.PollResponse, error)
+		NotifyClosedPolls(ctx context.Context) error
+	}
+
+	pollService struct {
+		pollRepository         PollRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewPollService(pollRepository PollRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) PollService {
+	return &pollService{
+		pollRepository:         pollRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+func (s *pollService) toResponse(ctx context.Context, poll entities.Poll, viewerID uuid.UUID) (domain.PollResponse, error) {
+	results, err := s.pollRepository.GetResults(ctx, []uuid.UUID{poll.ID}, viewerID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrGetPoll
+	}
+
+	post := *poll.Post
+	post.Poll = &poll
+
+	return *ToPollResponse(post, viewerID, results), nil
+}
+
+func (s *pollService) GetPoll(ctx context.Context, pollID string, viewerID string) (domain.PollResponse, error) {
+	parsedPollID, err := uuid.Parse(pollID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrParseUUID
+	}
+
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	poll, err := s.pollRepository.GetPollByID(ctx, parsedPollID, parsedViewerID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrPollNotFound
+	}
+
+	return s.toResponse(ctx, poll, parsedViewerID)
+}
+
+func (s *pollService) VotePoll(ctx context.Context, req domain.VotePollRequest, userID string) (domain.PollResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrParseUUID
+	}
+
+	parsedPollID, err := uuid.Parse(req.PollID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrParseUUID
+	}
+
+	parsedOptionID, err := uuid.Parse(req.OptionID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrParseUUID
+	}
+
+	poll, err := s.pollRepository.GetPollByID(ctx, parsedPollID, parsedUserID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrPollNotFound
+	}
+
+	if !poll.ClosesAt.After(time.Now()) {
+		return domain.PollResponse{}, domain.ErrPollClosed
+	}
+
+	found := false
+	for _, option := range poll.Options {
+		if option.ID == parsedOptionID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return domain.PollResponse{}, domain.ErrPollOptionNotFound
+	}
+
+	voted, err := s.pollRepository.HasVoted(ctx, poll.ID, parsedUserID)
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrVotePoll
+	}
+
+	if voted {
+		return domain.PollResponse{}, domain.ErrAlreadyVoted
+	}
+
+	// A concurrent second vote still fails on the unique index.
+	err = s.pollRepository.CreateVote(ctx, entities.PollVote{
+		PollID:   poll.ID,
+		UserID:   parsedUserID,
+		OptionID: parsedOptionID,
+	})
+
+	if err != nil {
+		return domain.PollResponse{}, domain.ErrVotePoll
+	}
+
+	return s.toResponse(ctx, poll, parsedUserID)
+}
+
+// NotifyClosedPolls sends the authors of closed polls their final results.
+// It is run periodically by the scheduler started in the app config. A poll
+// that fails is retried on the next run without holding up the others.
+func (s *pollService) NotifyClosedPolls(ctx context.Context) error {
+	polls, err := s.pollRepository.GetClosedPolls(ctx, time.Now(), domain.PollCloseBatch)
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, poll := range polls {
+		results, err := s.pollRepository.GetResults(ctx, []uuid.UUID{poll.ID}, uuid.Nil)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notify closed poll %s: %w", poll.ID, err))
+			continue
+		}
+
+		_, err = s.pollRepository.NotifyResults(ctx, poll.ID, time.Now(), entities.Notification{
+			UserID:           poll.Post.UserID,
+			Title:            "Poll Closed",
+			Message:          summarizeResults(poll, results),
+			IsRead:           false,
+			NotificationType: domain.NotificationTypePoll,
+			ReferenceID:      &poll.PostID,
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notify closed poll %s: %w", poll.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func summarizeResults(poll entities.Poll, results Results) string {
+	var total int64
+	for _, option := range poll.Options {
+		total += results.Counts[option.ID]
+	}
+
+	parts := make([]string, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		parts = append(parts, fmt.Sprintf("%s %d%%", option.Text, percentage(results.Counts[option.ID], total)))
+	}
+
+	return fmt.Sprintf("Your poll \"%s\" closed with %d votes: %s", poll.Question, total, strings.Join(parts, ", "))
+}
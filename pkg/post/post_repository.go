@@ -1,8 +1,15 @@
 package post
 
 import (
+	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/tag"
+	"Go-Starter-Template/pkg/visibility"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -10,11 +17,20 @@ import (
 
 type (
 	PostRepository interface {
-		CreatePost(ctx context.Context, post entities.Post) error
-		UpdatePost(ctx context.Context, post entities.Post) error
+		CreatePost(ctx context.Context, post entities.Post, hashtagNames []string, mentions []entities.PostMention) (entities.Post, error)
+		UpdatePost(ctx context.Context, post entities.Post, revision *entities.PostRevision, hashtagNames []string, mentions []entities.PostMention) error
 		DeletePost(ctx context.Context, postID uuid.UUID) error
 		GetPostByID(ctx context.Context, postID uuid.UUID) (entities.Post, error)
-		GetFeed(ctx context.Context) ([]entities.Post, error)
+		ReplaceAttachments(ctx context.Context, postID uuid.UUID, attachments []entities.PostAttachment) error
+		PublishPost(ctx context.Context, postID uuid.UUID, publishedAt time.Time) (bool, error)
+		UpdatePostSchedule(ctx context.Context, postID uuid.UUID, status string, publishAt *time.Time) error
+		GetUnpublishedPosts(ctx context.Context, userID uuid.UUID, status string, pagination domain.PaginationRequest) ([]entities.Post, error)
+		GetDuePosts(ctx context.Context, now time.Time, limit int) ([]entities.Post, error)
+		GetVisiblePost(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error)
+		GetPostRevisions(ctx context.Context, postID uuid.UUID, pagination domain.PaginationRequest) ([]entities.PostRevision, error)
+		GetExpiredRevisions(ctx context.Context, before time.Time, limit int) ([]entities.PostRevision, error)
+		IsFileInUse(ctx context.Context, url string, since time.Time) (bool, error)
+		ClearRevisionFiles(ctx context.Context, revisionID uuid.UUID) error
 	}
 
 	postRepository struct {
@@ -26,40 +42,246 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 	return &postRepository{db: db}
 }
 
-func (r *postRepository) CreatePost(ctx context.Context, post entities.Post) error {
-	if err := r.db.WithContext(ctx).Create(&post).Error; err != nil {
-		return err
+// CreatePost stores the post together with its hashtags and mentions.
+func (r *postRepository) CreatePost(ctx context.Context, post entities.Post, hashtagNames []string, mentions []entities.PostMention) (entities.Post, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&post).Error; err != nil {
+			return err
+		}
+		return tag.SavePostTags(tx, post.ID, hashtagNames, mentions)
+	})
+
+	if err != nil {
+		return entities.Post{}, err
 	}
-	return nil
+	return post, nil
 }
 
-func (r *postRepository) UpdatePost(ctx context.Context, post entities.Post) error {
-	if err := r.db.WithContext(ctx).Save(&post).Error; err != nil {
-		return err
-	}
-	return nil
+// UpdatePost saves the edited fields of the post with its hashtags and
+// mentions and, when revision is set, records the version it replaces in the
+// same transaction. Other columns, such as hidden_at, are left as they are.
+func (r *postRepository) UpdatePost(ctx context.Context, post entities.Post, revision *entities.PostRevision, hashtagNames []string, mentions []entities.PostMention) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if revision != nil {
+			if err := tx.Create(revision).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&entities.Post{}).Where("id = ?", post.ID).
+			Select("content", "asset", "visibility", "edited_at").
+			Updates(&post).Error; err != nil {
+			return err
+		}
+		return tag.SavePostTags(tx, post.ID, hashtagNames, mentions)
+	})
 }
 
+// DeletePost deletes the post together with its comments, reactions, tags,
+// poll and attachments. Revisions are kept until their files are purged.
 func (r *postRepository) DeletePost(ctx context.Context, postID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		pollIDs := tx.Model(&entities.Poll{}).Select("id").Where("post_id = ?", postID)
 
-	if err := r.db.WithContext(ctx).Delete(&entities.Post{}, postID).Error; err != nil {
-		return err
-	}
-	return nil
+		if err := tx.Where("poll_id IN (?)", pollIDs).Delete(&entities.PollVote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("poll_id IN (?)", pollIDs).Delete(&entities.PollOption{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&entities.Poll{},
+			&entities.PostComment{},
+			&entities.PostReaction{},
+			&entities.PostHashtag{},
+			&entities.PostMention{},
+			&entities.PostAttachment{},
+		} {
+			if err := tx.Where("post_id = ?", postID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&entities.Post{}, postID).Error; err != nil {
+			return err
+		}
+		return nil
+	})
 }
 
 func (r *postRepository) GetPostByID(ctx context.Context, postID uuid.UUID) (entities.Post, error) {
 	var post entities.Post
-	if err := r.db.WithContext(ctx).First(&post, "id = ?", postID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Attachments").Preload("Poll").First(&post, "id = ?", postID).Error; err != nil {
 		return entities.Post{}, err
 	}
 	return post, nil
 }
 
-func (r *postRepository) GetFeed(ctx context.Context) ([]entities.Post, error) {
+func (r *postRepository) ReplaceAttachments(ctx context.Context, postID uuid.UUID, attachments []entities.PostAttachment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostAttachment{}).Error; err != nil {
+			return err
+		}
+
+		if len(attachments) == 0 {
+			return nil
+		}
+
+		for i := range attachments {
+			attachments[i].PostID = postID
+		}
+
+		if err := tx.Create(&attachments).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// PublishPost marks an unpublished post as published and dates it at
+// publishedAt. It reports false when the post was already published, so a
+// post is never announced twice.
+func (r *postRepository) PublishPost(ctx context.Context, postID uuid.UUID, publishedAt time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND status <> ?", postID, domain.PostStatusPublished).
+		Updates(map[string]interface{}{
+			"status":     domain.PostStatusPublished,
+			"publish_at": nil,
+			"created_at": publishedAt,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *postRepository) UpdatePostSchedule(ctx context.Context, postID uuid.UUID, status string, publishAt *time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Where("id = ? AND status <> ?", postID, domain.PostStatusPublished).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"publish_at": publishAt,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *postRepository) GetUnpublishedPosts(ctx context.Context, userID uuid.UUID, status string, pagination domain.PaginationRequest) ([]entities.Post, error) {
 	var posts []entities.Post
-	if err := r.db.WithContext(ctx).Preload("User").Order("created_at desc").Find(&posts).Error; err != nil {
+
+	query := r.db.WithContext(ctx).
+		Preload("Attachments", attachment.InOrder).
+		Where("user_id = ? AND status = ?", userID, status)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&posts).Error; err != nil {
 		return nil, err
 	}
 	return posts, nil
 }
+
+func (r *postRepository) GetDuePosts(ctx context.Context, now time.Time, limit int) ([]entities.Post, error) {
+	var posts []entities.Post
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND publish_at <= ?", domain.PostStatusScheduled, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *postRepository) GetVisiblePost(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error) {
+	var post entities.Post
+	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		First(&post, "id = ?", postID).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *postRepository) GetPostRevisions(ctx context.Context, postID uuid.UUID, pagination domain.PaginationRequest) ([]entities.PostRevision, error) {
+	var revisions []entities.PostRevision
+
+	query := r.db.WithContext(ctx).
+		Preload("Attachments", attachment.InOrder).
+		Where("post_id = ?", postID)
+
+	if pagination.Cursor != "" {
+		createdAt, id, err := utils.DecodeTimeCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(pagination.Limit + 1).Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetExpiredRevisions returns the revisions recorded before the given time
+// that still list a file, oldest first.
+func (r *postRepository) GetExpiredRevisions(ctx context.Context, before time.Time, limit int) ([]entities.PostRevision, error) {
+	var revisions []entities.PostRevision
+	if err := r.db.WithContext(ctx).
+		Preload("Attachments").
+		Where("created_at < ?", before).
+		Where(`asset <> '' OR EXISTS (
+			SELECT 1 FROM post_revision_attachments pra
+			WHERE pra.revision_id = post_revisions.id AND pra.deleted_at IS NULL
+		)`).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// IsFileInUse reports whether the file at url is still shown by a post, or
+// by a revision recorded since the given time.
+func (r *postRepository) IsFileInUse(ctx context.Context, url string, since time.Time) (bool, error) {
+	var inUse bool
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT EXISTS (SELECT 1 FROM post_attachments WHERE url = ? AND deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM posts WHERE asset = ? AND deleted_at IS NULL)
+			OR EXISTS (
+				SELECT 1 FROM post_revision_attachments pra
+				JOIN post_revisions pr ON pr.id = pra.revision_id
+				WHERE pra.url = ? AND pra.deleted_at IS NULL AND pr.created_at >= ? AND pr.deleted_at IS NULL
+			)
+			OR EXISTS (SELECT 1 FROM post_revisions WHERE asset = ? AND created_at >= ? AND deleted_at IS NULL)
+	`, url, url, url, since, url, since).Scan(&inUse).Error; err != nil {
+		return false, err
+	}
+	return inUse, nil
+}
+
+// ClearRevisionFiles drops the asset and attachments of a revision, leaving
+// its content in the history.
+func (r *postRepository) ClearRevisionFiles(ctx context.Context, revisionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("revision_id = ?", revisionID).Delete(&entities.PostRevisionAttachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entities.PostRevision{}).Where("id = ?", revisionID).Update("asset", "").Error
+	})
+}
@@ -1,13 +1,21 @@
 package post
 
 import (
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/company"
 	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"Go-Starter-Template/pkg/tag"
 
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
 	"Go-Starter-Template/internal/utils"
 	"Go-Starter-Template/internal/utils/storage"
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,18 +25,34 @@ type (
 		CreatePost(ctx context.Context, req domain.CreatePostRequest, userID string) error
 		UpdatePost(ctx context.Context, req domain.UpdatePostRequest, userID string) error
 		DeletePost(ctx context.Context, postID string, userID string) error
-		GetFeed(ctx context.Context) ([]domain.PostResponse, error)
+		PublishPost(ctx context.Context, postID string, userID string) error
+		SchedulePost(ctx context.Context, req domain.SchedulePostRequest, userID string) error
+		CancelScheduledPost(ctx context.Context, postID string, userID string) error
+		GetUnpublishedPosts(ctx context.Context, userID string, status string, pagination domain.PaginationRequest) ([]domain.UnpublishedPostResponse, domain.PaginationResponse, error)
+		PublishDuePosts(ctx context.Context) error
+		GetPostRevisions(ctx context.Context, postID string, viewerID string, viewerRole string, pagination domain.PaginationRequest) ([]domain.PostRevisionResponse, domain.PaginationResponse, error)
+		PurgeRevisionFiles(ctx context.Context) error
 	}
 
 	postService struct {
-		postRepository PostRepository
-		awsS3          storage.AwsS3
-		jwtService     jwtService.JWTService
+		postRepository         PostRepository
+		companyRepository      company.CompanyRepository
+		notificationRepository notification.NotificationRepository
+		tagRepository          tag.TagRepository
+		awsS3                  storage.AwsS3
+		jwtService             jwtService.JWTService
 	}
 )
 
-func NewPostService(postRepository PostRepository, awsS3 storage.AwsS3, jwtService jwtService.JWTService) PostService {
-	return &postService{postRepository: postRepository, awsS3: awsS3, jwtService: jwtService}
+func NewPostService(postRepository PostRepository, companyRepository company.CompanyRepository, notificationRepository notification.NotificationRepository, tagRepository tag.TagRepository, awsS3 storage.AwsS3, jwtService jwtService.JWTService) PostService {
+	return &postService{
+		postRepository:         postRepository,
+		companyRepository:      companyRepository,
+		notificationRepository: notificationRepository,
+		tagRepository:          tagRepository,
+		awsS3:                  awsS3,
+		jwtService:             jwtService,
+	}
 }
 
 func (s *postService) CreatePost(ctx context.Context, req domain.CreatePostRequest, userID string) error {
@@ -38,31 +62,253 @@ func (s *postService) CreatePost(ctx context.Context, req domain.CreatePostReque
 		return domain.ErrParseUUID
 	}
 
+	if req.Visibility == "" {
+		req.Visibility = domain.PostVisibilityPublic
+	}
+
 	post := entities.Post{
-		UserID:  parsedUserID,
-		Content: req.Content,
+		UserID:     parsedUserID,
+		Content:    req.Content,
+		Visibility: req.Visibility,
+		Status:     domain.PostStatusPublished,
 	}
 
-	allowedMimetype := []string{"image/jpeg", "image/jpg", "image/png"}
+	if req.PublishAt != "" {
+		publishAt, err := parsePublishAt(req.PublishAt)
 
-	if req.Asset != nil {
-		objectKey, err := s.awsS3.UploadFile(utils.GenerateRandomFileName(userID), req.Asset, "posts", allowedMimetype...)
 		if err != nil {
-			return domain.ErrUploadFile
+			return err
+		}
+
+		post.Status = domain.PostStatusScheduled
+		post.PublishAt = &publishAt
+	} else if req.IsDraft {
+		post.Status = domain.PostStatusDraft
+	}
+
+	if req.Poll != nil {
+		if len(req.Attachments) > 0 {
+			return domain.ErrPollWithAttachments
 		}
 
-		post.Asset = s.awsS3.GetPublicLinkKey(objectKey)
+		post.Poll, err = newPoll(*req.Poll, post.PublishAt)
+
+		if err != nil {
+			return err
+		}
 	}
 
-	err = s.postRepository.CreatePost(ctx, post)
+	uploads, err := attachment.Inspect(req.Attachments)
 
 	if err != nil {
+		return err
+	}
+
+	attachments, err := s.uploadAttachments(userID, uploads)
+
+	if err != nil {
+		return err
+	}
+
+	hashtagNames, mentions, err := s.resolveTags(ctx, post)
+
+	if err != nil {
+		s.deleteAttachmentObjects(attachments)
+		return err
+	}
+
+	post.Attachments = attachments
+	post, err = s.postRepository.CreatePost(ctx, post, hashtagNames, mentions)
+
+	if err != nil {
+		s.deleteAttachmentObjects(attachments)
 		return domain.ErrCreatePost
 	}
 
+	if post.Status == domain.PostStatusPublished {
+		s.announcePost(ctx, post, mentions)
+	}
+
 	return nil
 }
 
+// parsePublishAt reads a client supplied publish time into the server's
+// local time, which is how post timestamps are stored.
+func parsePublishAt(value string) (time.Time, error) {
+	publishAt, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return time.Time{}, domain.ErrInvalidPublishAt
+	}
+
+	if !publishAt.After(time.Now()) {
+		return time.Time{}, domain.ErrPublishAtInPast
+	}
+
+	return publishAt.Local(), nil
+}
+
+// newPoll builds the poll of a new post. It has to close within the
+// maximum poll duration of the post going live.
+func newPoll(req domain.CreatePollRequest, publishAt *time.Time) (*entities.Poll, error) {
+	closesAt, err := time.Parse(time.RFC3339, req.ClosesAt)
+
+	if err != nil {
+		return nil, domain.ErrInvalidPollClosesAt
+	}
+
+	opensAt := time.Now()
+	if publishAt != nil {
+		opensAt = *publishAt
+	}
+
+	if !closesAt.After(opensAt) || closesAt.Sub(opensAt) > domain.PollMaxDuration {
+		return nil, domain.ErrPollDuration
+	}
+
+	poll := &entities.Poll{
+		Question: req.Question,
+		ClosesAt: closesAt.Local(),
+	}
+
+	for i, text := range req.Options {
+		poll.Options = append(poll.Options, entities.PollOption{Text: text, Position: i})
+	}
+
+	return poll, nil
+}
+
+// announcePost sends the notifications that go out when a post is published.
+func (s *postService) announcePost(ctx context.Context, post entities.Post, mentions []entities.PostMention) {
+	s.notifyMentions(ctx, post, mentions)
+	s.notifyCompanyFollowers(ctx, post)
+}
+
+// uploadAttachments stores the inspected files in order. If one of them
+// fails, the ones already stored are removed again.
+func (s *postService) uploadAttachments(userID string, uploads []attachment.Upload) ([]entities.PostAttachment, error) {
+	attachments := make([]entities.PostAttachment, 0, len(uploads))
+
+	for i, upload := range uploads {
+		objectKey, err := s.awsS3.UploadFile(utils.GenerateRandomFileName(userID), upload.File, "posts", upload.MimeType)
+
+		if err != nil {
+			s.deleteAttachmentObjects(attachments)
+			return nil, domain.ErrUploadFile
+		}
+
+		attachments = append(attachments, entities.PostAttachment{
+			ObjectKey: objectKey,
+			URL:       s.awsS3.GetPublicLinkKey(objectKey),
+			MimeType:  upload.MimeType,
+			Size:      upload.File.Size,
+			Width:     upload.Width,
+			Height:    upload.Height,
+			Position:  i,
+		})
+	}
+
+	return attachments, nil
+}
+
+// deleteAttachmentObjects removes stored files on a best effort basis; a
+// leftover object is not worth failing the request over.
+func (s *postService) deleteAttachmentObjects(attachments []entities.PostAttachment) {
+	for _, stored := range attachments {
+		_ = s.awsS3.DeleteFile(stored.ObjectKey)
+	}
+}
+
+// notifyCompanyFollowers tells the followers of a company that it published
+// a new post. Posts written by regular users are ignored.
+func (s *postService) notifyCompanyFollowers(ctx context.Context, post entities.Post) {
+	author, err := s.companyRepository.GetCompanyByUserID(ctx, post.UserID)
+
+	if err != nil {
+		return
+	}
+
+	message := post.Content
+	if runes := []rune(message); len(runes) > 100 {
+		message = string(runes[:100]) + "..."
+	}
+
+	company.NotifyFollowers(ctx, s.companyRepository, s.notificationRepository, author, "New post from "+author.Name, message)
+}
+
+// resolveTags finds the hashtags and the mentioned accounts in the post
+// content. They are stored along with the post.
+func (s *postService) resolveTags(ctx context.Context, post entities.Post) ([]string, []entities.PostMention, error) {
+	hashtagNames, slugs := tag.ExtractTags(post.Content)
+
+	mentions, err := s.tagRepository.ResolveMentions(ctx, slugs, post.UserID)
+
+	if err != nil {
+		return nil, nil, domain.ErrTagPost
+	}
+
+	return hashtagNames, mentions, nil
+}
+
+// addedMentions keeps the mentions of accounts that were not in previous.
+func addedMentions(previous []entities.PostMention, mentions []entities.PostMention) []entities.PostMention {
+	mentioned := make(map[uuid.UUID]bool, len(previous))
+	for _, mention := range previous {
+		mentioned[mention.UserID] = true
+	}
+
+	added := make([]entities.PostMention, 0, len(mentions))
+	for _, mention := range mentions {
+		if !mentioned[mention.UserID] {
+			added = append(added, mention)
+		}
+	}
+
+	return added
+}
+
+// notifyMentions tells the mentioned accounts about the post, once per
+// account and never the author. The post is already stored, so failures are
+// only logged.
+func (s *postService) notifyMentions(ctx context.Context, post entities.Post, mentions []entities.PostMention) {
+	notified := map[uuid.UUID]bool{post.UserID: true}
+
+	var recipientIDs []uuid.UUID
+	for _, mention := range mentions {
+		if !notified[mention.UserID] {
+			notified[mention.UserID] = true
+			recipientIDs = append(recipientIDs, mention.UserID)
+		}
+	}
+
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	author, err := s.tagRepository.GetUserByID(ctx, post.UserID)
+
+	if err != nil {
+		log.Printf("notify mentions of post %s: %v", post.ID, err)
+		return
+	}
+
+	notifications := make([]entities.Notification, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		notifications = append(notifications, entities.Notification{
+			UserID:           recipientID,
+			Title:            "New Mention",
+			Message:          author.Name + " mentioned you in a post",
+			IsRead:           false,
+			NotificationType: domain.NotificationTypeMention,
+			ReferenceID:      &post.ID,
+		})
+	}
+
+	if err := s.notificationRepository.CreateNotifications(ctx, notifications); err != nil {
+		log.Printf("notify mentions of post %s: %v", post.ID, err)
+	}
+}
+
 func (s *postService) UpdatePost(ctx context.Context, req domain.UpdatePostRequest, userID string) error {
 	parsedUserID, err := uuid.Parse(userID)
 
@@ -86,30 +332,103 @@ func (s *postService) UpdatePost(ctx context.Context, req domain.UpdatePostReque
 		return domain.ErrUserNotAllowed
 	}
 
+	if post.HiddenAt != nil {
+		return domain.ErrPostHidden
+	}
+
+	replaceAttachments := len(req.Attachments) > 0 || req.RemoveAttachments
+	oldAttachments := post.Attachments
+	oldAsset := post.Asset
+
+	if req.Visibility == "" {
+		req.Visibility = post.Visibility
+	}
+
+	// Edits to a published post keep the version they replace, so what was
+	// once public can still be looked into after it changed.
+	var revision *entities.PostRevision
+
+	if post.Status == domain.PostStatusPublished && (replaceAttachments || req.Content != post.Content) {
+		revision = newPostRevision(post)
+		editedAt := time.Now()
+		post.EditedAt = &editedAt
+	}
+
 	post = entities.Post{
-		ID:      parsedPostID,
-		UserID:  parsedUserID,
-		Content: req.Content,
-		Asset:   post.Asset,
+		ID:           parsedPostID,
+		UserID:       parsedUserID,
+		Content:      req.Content,
+		Asset:        post.Asset,
+		SharedPostID: post.SharedPostID,
+		Visibility:   req.Visibility,
+		Status:       post.Status,
+		PublishAt:    post.PublishAt,
+		EditedAt:     post.EditedAt,
+		Timestamp:    entities.Timestamp{CreatedAt: post.CreatedAt},
 	}
 
-	if req.Asset != nil {
-		allowedMimetype := []string{"image/jpeg", "image/jpg", "image/png"}
+	var newAttachments []entities.PostAttachment
+
+	if replaceAttachments {
+		uploads, err := attachment.Inspect(req.Attachments)
 
-		objectKey, err := s.awsS3.UploadFile(utils.GenerateRandomFileName(req.Content), req.Asset, "posts", allowedMimetype...)
 		if err != nil {
-			return domain.ErrUploadFile
+			return err
 		}
 
-		post.Asset = s.awsS3.GetPublicLinkKey(objectKey)
+		newAttachments, err = s.uploadAttachments(userID, uploads)
+
+		if err != nil {
+			return err
+		}
+
+		post.Asset = ""
+	}
+
+	hashtagNames, mentions, err := s.resolveTags(ctx, post)
+
+	if err != nil {
+		s.deleteAttachmentObjects(newAttachments)
+		return err
+	}
+
+	previousMentions, err := s.tagRepository.GetPostMentions(ctx, post.ID)
+
+	if err != nil {
+		s.deleteAttachmentObjects(newAttachments)
+		return domain.ErrTagPost
 	}
 
-	err = s.postRepository.UpdatePost(ctx, post)
+	err = s.postRepository.UpdatePost(ctx, post, revision, hashtagNames, mentions)
 
 	if err != nil {
+		s.deleteAttachmentObjects(newAttachments)
 		return domain.ErrUpdatePost
 	}
 
+	if replaceAttachments {
+		err = s.postRepository.ReplaceAttachments(ctx, parsedPostID, newAttachments)
+
+		if err != nil {
+			s.deleteAttachmentObjects(newAttachments)
+			return domain.ErrUpdatePost
+		}
+	}
+
+	// Files of a recorded revision stay in storage for its history until
+	// PurgeRevisionFiles deletes them.
+	if replaceAttachments && revision == nil {
+		s.deleteAttachmentObjects(oldAttachments)
+
+		if objectKey := s.awsS3.GetObjectKeyFromLink(oldAsset); objectKey != "" {
+			_ = s.awsS3.DeleteFile(objectKey)
+		}
+	}
+
+	if post.Status == domain.PostStatusPublished {
+		s.notifyMentions(ctx, post, addedMentions(previousMentions, mentions))
+	}
+
 	return nil
 }
 
@@ -142,36 +461,341 @@ func (s *postService) DeletePost(ctx context.Context, postID string, userID stri
 		return domain.ErrDeletePost
 	}
 
+	s.deleteAttachmentObjects(post.Attachments)
+
+	if objectKey := s.awsS3.GetObjectKeyFromLink(post.Asset); objectKey != "" {
+		_ = s.awsS3.DeleteFile(objectKey)
+	}
+
+	return nil
+}
+
+// getOwnUnpublishedPost loads a draft or scheduled post of the user.
+func (s *postService) getOwnUnpublishedPost(ctx context.Context, postID string, userID string) (entities.Post, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return entities.Post{}, domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return entities.Post{}, domain.ErrParseUUID
+	}
+
+	post, err := s.postRepository.GetPostByID(ctx, parsedPostID)
+
+	if err != nil {
+		return entities.Post{}, domain.ErrPostNotFound
+	}
+
+	if post.UserID != parsedUserID {
+		return entities.Post{}, domain.ErrUserNotAllowed
+	}
+
+	if post.Status == domain.PostStatusPublished {
+		return entities.Post{}, domain.ErrPostAlreadyPublished
+	}
+
+	return post, nil
+}
+
+// publish makes a draft or scheduled post public and sends the
+// notifications that were held back while it was unpublished. The post is
+// dated at the time it is published so it ranks as new.
+func (s *postService) publish(ctx context.Context, post entities.Post) error {
+	published, err := s.postRepository.PublishPost(ctx, post.ID, time.Now())
+
+	if err != nil {
+		return domain.ErrPublishPost
+	}
+
+	if !published {
+		return nil
+	}
+
+	// The post is live now, so a failed lookup only costs the mentions
+	// their notification.
+	mentions, err := s.tagRepository.GetPostMentions(ctx, post.ID)
+
+	if err != nil {
+		log.Printf("notify mentions of post %s: %v", post.ID, err)
+	}
+
+	s.announcePost(ctx, post, mentions)
+
+	return nil
+}
+
+func (s *postService) PublishPost(ctx context.Context, postID string, userID string) error {
+	post, err := s.getOwnUnpublishedPost(ctx, postID, userID)
+
+	if err != nil {
+		return err
+	}
+
+	if post.Poll != nil && !post.Poll.ClosesAt.After(time.Now()) {
+		return domain.ErrPollClosesBeforePublish
+	}
+
+	return s.publish(ctx, post)
+}
+
+func (s *postService) SchedulePost(ctx context.Context, req domain.SchedulePostRequest, userID string) error {
+	post, err := s.getOwnUnpublishedPost(ctx, req.ID, userID)
+
+	if err != nil {
+		return err
+	}
+
+	publishAt, err := parsePublishAt(req.PublishAt)
+
+	if err != nil {
+		return err
+	}
+
+	if post.Poll != nil && !post.Poll.ClosesAt.After(publishAt) {
+		return domain.ErrPollClosesBeforePublish
+	}
+
+	err = s.postRepository.UpdatePostSchedule(ctx, post.ID, domain.PostStatusScheduled, &publishAt)
+
+	if err != nil {
+		return domain.ErrUpdatePost
+	}
+
 	return nil
 }
 
-func (s *postService) GetFeed(ctx context.Context) ([]domain.PostResponse, error) {
+// CancelScheduledPost turns a scheduled post back into a draft.
+func (s *postService) CancelScheduledPost(ctx context.Context, postID string, userID string) error {
+	post, err := s.getOwnUnpublishedPost(ctx, postID, userID)
 
-	posts, err := s.postRepository.GetFeed(ctx)
+	if err != nil {
+		return err
+	}
+
+	if post.Status != domain.PostStatusScheduled {
+		return domain.ErrPostNotScheduled
+	}
+
+	err = s.postRepository.UpdatePostSchedule(ctx, post.ID, domain.PostStatusDraft, nil)
 
 	if err != nil {
-		return nil, domain.ErrGetFeed
+		return domain.ErrUpdatePost
 	}
 
-	var postResponses []domain.PostResponse
+	return nil
+}
+
+func (s *postService) GetUnpublishedPosts(ctx context.Context, userID string, status string, pagination domain.PaginationRequest) ([]domain.UnpublishedPostResponse, domain.PaginationResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+	}
+
+	posts, err := s.postRepository.GetUnpublishedPosts(ctx, parsedUserID, status, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetUnpublishedPosts
+	}
+
+	posts, hasMore := utils.TrimPage(posts, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := posts[len(posts)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	postsResponse := make([]domain.UnpublishedPostResponse, 0, len(posts))
 
 	for _, post := range posts {
-		postResponses = append(postResponses, domain.PostResponse{
-			ID:             post.ID.String(),
-			Name:           post.User.Name,
-			Headline:       post.User.CurrentTitle,
-			ProfilePicture: post.User.ProfilePicture,
-			Content:        post.Content,
-			Asset:          post.Asset,
-			CreatedAt:      utils.ConvertTimeToString(post.CreatedAt),
-			Slug:           post.User.Slug,
-			Type:           post.User.Role,
+		res := domain.UnpublishedPostResponse{
+			ID:          post.ID.String(),
+			Content:     post.Content,
+			Visibility:  post.Visibility,
+			Status:      post.Status,
+			CreatedAt:   utils.ConvertTimeToString(post.CreatedAt),
+			Attachments: attachment.ToAttachmentResponses(post.Attachments),
+		}
+
+		if post.PublishAt != nil {
+			res.PublishAt = post.PublishAt.Format(time.RFC3339)
+		}
+
+		postsResponse = append(postsResponse, res)
+	}
+
+	return postsResponse, meta, nil
+}
+
+// PublishDuePosts publishes the scheduled posts whose time has come. It is
+// run periodically by the scheduler started in the app config.
+func (s *postService) PublishDuePosts(ctx context.Context) error {
+	posts, err := s.postRepository.GetDuePosts(ctx, time.Now(), domain.PostPublishBatch)
+
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if err := s.publish(ctx, post); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newPostRevision(post entities.Post) *entities.PostRevision {
+	revision := &entities.PostRevision{
+		PostID:  post.ID,
+		Content: post.Content,
+		Asset:   post.Asset,
+	}
+
+	for _, stored := range post.Attachments {
+		revision.Attachments = append(revision.Attachments, entities.PostRevisionAttachment{
+			ObjectKey: stored.ObjectKey,
+			URL:       stored.URL,
+			MimeType:  stored.MimeType,
+			Size:      stored.Size,
+			Width:     stored.Width,
+			Height:    stored.Height,
+			Position:  stored.Position,
 		})
 	}
 
-	if postResponses == nil {
-		return []domain.PostResponse{}, nil
+	return revision
+}
+
+// GetPostRevisions lists the earlier versions of a post the viewer can see,
+// most recent first. Admins can see the revisions of any post so hidden posts
+// can be reviewed.
+func (s *postService) GetPostRevisions(ctx context.Context, postID string, viewerID string, viewerRole string, pagination domain.PaginationRequest) ([]domain.PostRevisionResponse, domain.PaginationResponse, error) {
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrParseUUID
+	}
+
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	var post entities.Post
+
+	if viewerRole == domain.RoleAdmin {
+		post, err = s.postRepository.GetPostByID(ctx, parsedPostID)
+	} else {
+		post, err = s.postRepository.GetVisiblePost(ctx, parsedPostID, parsedViewerID)
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrPostNotFound
+	}
+
+	revisions, err := s.postRepository.GetPostRevisions(ctx, post.ID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrGetPostRevisions
+	}
+
+	revisions, hasMore := utils.TrimPage(revisions, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := revisions[len(revisions)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeTimeCursor(last.CreatedAt, last.ID), HasMore: true}
+	}
+
+	revisionsResponse := make([]domain.PostRevisionResponse, 0, len(revisions))
+
+	for _, revision := range revisions {
+		attachments := make([]entities.PostAttachment, 0, len(revision.Attachments))
+		for _, stored := range revision.Attachments {
+			attachments = append(attachments, entities.PostAttachment{
+				URL:      stored.URL,
+				MimeType: stored.MimeType,
+				Size:     stored.Size,
+				Width:    stored.Width,
+				Height:   stored.Height,
+				Position: stored.Position,
+			})
+		}
+
+		revisionsResponse = append(revisionsResponse, domain.PostRevisionResponse{
+			ID:          revision.ID.String(),
+			Content:     revision.Content,
+			Asset:       revision.Asset,
+			EditedAt:    utils.ConvertTimeToString(revision.CreatedAt),
+			Attachments: attachment.ToAttachmentResponses(attachments),
+		})
+	}
+
+	return revisionsResponse, meta, nil
+}
+
+// PurgeRevisionFiles deletes the files of revisions recorded more than
+// domain.PostRevisionFileRetention ago, so the attachments a post was edited
+// away from do not stay in storage forever. A file still shown by a post or
+// a newer revision is kept; the old revision only stops listing it.
+func (s *postService) PurgeRevisionFiles(ctx context.Context) error {
+	cutoff := time.Now().Add(-domain.PostRevisionFileRetention)
+
+	revisions, err := s.postRepository.GetExpiredRevisions(ctx, cutoff, domain.PostRevisionPurgeBatch)
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, revision := range revisions {
+		if err := s.purgeRevisionFiles(ctx, revision, cutoff); err != nil {
+			errs = append(errs, fmt.Errorf("purge files of post revision %s: %w", revision.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *postService) purgeRevisionFiles(ctx context.Context, revision entities.PostRevision, cutoff time.Time) error {
+	// object keys by url
+	files := make(map[string]string, len(revision.Attachments)+1)
+	if revision.Asset != "" {
+		files[revision.Asset] = s.awsS3.GetObjectKeyFromLink(revision.Asset)
+	}
+	for _, stored := range revision.Attachments {
+		files[stored.URL] = stored.ObjectKey
+	}
+
+	for url, objectKey := range files {
+		inUse, err := s.postRepository.IsFileInUse(ctx, url, cutoff)
+
+		if err != nil {
+			return err
+		}
+
+		if inUse || objectKey == "" {
+			continue
+		}
+
+		if err := s.awsS3.DeleteFile(objectKey); err != nil {
+			return err
+		}
 	}
 
-	return postResponses, nil
+	return s.postRepository.ClearRevisionFiles(ctx, revision.ID)
 }
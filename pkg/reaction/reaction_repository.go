@@ -0,0 +1,143 @@
+package reaction
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ReactionRepository interface {
+		GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error)
+		GetReaction(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (entities.PostReaction, error)
+		UpsertReaction(ctx context.Context, reaction entities.PostReaction) error
+		DeleteReaction(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (bool, error)
+		CountReactors(ctx context.Context, postID uuid.UUID, excludeUserID uuid.UUID) (int64, error)
+		GetReactionSummaries(ctx context.Context, postIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]domain.ReactionSummaryResponse, error)
+	}
+
+	reactionRepository struct {
+		db *gorm.DB
+	}
+
+	reactionCountRow struct {
+		PostID uuid.UUID
+		Type   string
+		Count  int64
+	}
+)
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
+
+// GetPostByID finds a post the viewer is allowed to see.
+func (r *reactionRepository) GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error) {
+	var post entities.Post
+	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		First(&post, "id = ?", postID).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *reactionRepository) GetReaction(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (entities.PostReaction, error) {
+	var reaction entities.PostReaction
+	if err := r.db.WithContext(ctx).Where("post_id = ? AND user_id = ?", postID, userID).First(&reaction).Error; err != nil {
+		return entities.PostReaction{}, err
+	}
+	return reaction, nil
+}
+
+// UpsertReaction stores the user's reaction, replacing the type of any
+// reaction they already left on the post.
+func (r *reactionRepository) UpsertReaction(ctx context.Context, reaction entities.PostReaction) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "updated_at"}),
+	}).Create(&reaction).Error
+}
+
+func (r *reactionRepository) DeleteReaction(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Delete(&entities.PostReaction{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *reactionRepository) CountReactors(ctx context.Context, postID uuid.UUID, excludeUserID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostReaction{}).
+		Where("post_id = ? AND user_id <> ?", postID, excludeUserID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetReactionSummaries returns the per-type counts of every post in postIDs,
+// with the viewer's own reaction when viewerID is set. Posts without
+// reactions get zero counts.
+func (r *reactionRepository) GetReactionSummaries(ctx context.Context, postIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]domain.ReactionSummaryResponse, error) {
+	summaries := make(map[uuid.UUID]domain.ReactionSummaryResponse, len(postIDs))
+
+	for _, postID := range postIDs {
+		counts := make(map[string]int64, len(domain.ReactionTypes))
+		for _, reactionType := range domain.ReactionTypes {
+			counts[reactionType] = 0
+		}
+		summaries[postID] = domain.ReactionSummaryResponse{Counts: counts}
+	}
+
+	if len(postIDs) == 0 {
+		return summaries, nil
+	}
+
+	var rows []reactionCountRow
+	if err := r.db.WithContext(ctx).
+		Model(&entities.PostReaction{}).
+		Select("post_id, type, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id, type").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summary := summaries[row.PostID]
+		summary.Counts[row.Type] = row.Count
+		summary.Total += row.Count
+		summaries[row.PostID] = summary
+	}
+
+	if viewerID == uuid.Nil {
+		return summaries, nil
+	}
+
+	var own []entities.PostReaction
+	if err := r.db.WithContext(ctx).
+		Where("post_id IN ? AND user_id = ?", postIDs, viewerID).
+		Find(&own).Error; err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range own {
+		summary := summaries[reaction.PostID]
+		summary.ViewerReaction = reaction.Type
+		summaries[reaction.PostID] = summary
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,155 @@
+package reaction
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"Go-Starter-Template/pkg/user"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ReactionService interface {
+		ReactPost(ctx context.Context, req domain.ReactPostRequest, userID string) error
+		UnreactPost(ctx context.Context, postID string, userID string) error
+	}
+
+	reactionService struct {
+		reactionRepository     ReactionRepository
+		userRepository         user.UserRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewReactionService(reactionRepository ReactionRepository, userRepository user.UserRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) ReactionService {
+	return &reactionService{
+		reactionRepository:     reactionRepository,
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+func (s *reactionService) ReactPost(ctx context.Context, req domain.ReactPostRequest, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(req.PostID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	post, err := s.reactionRepository.GetPostByID(ctx, parsedPostID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrPostNotFound
+	}
+
+	_, err = s.reactionRepository.GetReaction(ctx, parsedPostID, parsedUserID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrReactPost
+	}
+
+	isNew := err != nil
+
+	err = s.reactionRepository.UpsertReaction(ctx, entities.PostReaction{
+		PostID: parsedPostID,
+		UserID: parsedUserID,
+		Type:   req.Type,
+	})
+
+	if err != nil {
+		return domain.ErrReactPost
+	}
+
+	if !isNew || post.UserID == parsedUserID {
+		return nil
+	}
+
+	return s.notifyAuthor(ctx, post, parsedUserID)
+}
+
+// notifyAuthor keeps a single unread reaction notification per post and
+// rewrites it as more people react, instead of sending one per reaction.
+func (s *reactionService) notifyAuthor(ctx context.Context, post entities.Post, reactorID uuid.UUID) error {
+	reactor, err := s.userRepository.GetUserByID(ctx, reactorID)
+
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	reactors, err := s.reactionRepository.CountReactors(ctx, post.ID, post.UserID)
+
+	if err != nil {
+		return err
+	}
+
+	message := reactor.Name + " reacted to your post"
+
+	switch others := reactors - 1; {
+	case others == 1:
+		message = reactor.Name + " and 1 other reacted to your post"
+	case others > 1:
+		message = fmt.Sprintf("%s and %d others reacted to your post", reactor.Name, others)
+	}
+
+	existing, err := s.notificationRepository.GetUnreadNotificationByReference(ctx, post.UserID, domain.NotificationTypeReaction, post.ID)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err == nil {
+		existing.Message = message
+		existing.CreatedAt = time.Now()
+		return s.notificationRepository.UpdateNotification(ctx, existing)
+	}
+
+	return s.notificationRepository.CreateNotification(ctx, entities.Notification{
+		UserID:           post.UserID,
+		Title:            "New Reactions",
+		Message:          message,
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeReaction,
+		ReferenceID:      &post.ID,
+	})
+}
+
+func (s *reactionService) UnreactPost(ctx context.Context, postID string, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(postID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	deleted, err := s.reactionRepository.DeleteReaction(ctx, parsedPostID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrUnreactPost
+	}
+
+	if !deleted {
+		return domain.ErrReactionNotFound
+	}
+
+	return nil
+}
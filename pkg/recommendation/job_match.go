@@ -0,0 +1,165 @@
+package recommendation
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	locationPreference struct {
+		Location     string
+		LocationType string
+	}
+
+	jobMatch struct {
+		Percentage     int
+		ExperienceFits bool
+		LocationFits   bool
+	}
+)
+
+// experienceYears adds up the time covered by the user's positions, counting
+// overlapping positions once. A position without an end date is current.
+func experienceYears(experiences []entities.UserExperience, now time.Time) float64 {
+	type period struct{ start, end time.Time }
+
+	periods := make([]period, 0, len(experiences))
+	for _, experience := range experiences {
+		end := experience.EndedAt
+		if end.IsZero() || end.After(now) {
+			end = now
+		}
+		if experience.StartedAt.IsZero() || !end.After(experience.StartedAt) {
+			continue
+		}
+		periods = append(periods, period{start: experience.StartedAt, end: end})
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start.Before(periods[j].start)
+	})
+
+	var total time.Duration
+	var current *period
+	for i := range periods {
+		if current != nil && !periods[i].start.After(current.end) {
+			if periods[i].end.After(current.end) {
+				current.end = periods[i].end
+			}
+			continue
+		}
+		if current != nil {
+			total += current.end.Sub(current.start)
+		}
+		current = &periods[i]
+	}
+	if current != nil {
+		total += current.end.Sub(current.start)
+	}
+
+	return total.Hours() / (24 * 365.25)
+}
+
+func normalizeLevel(level string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, level)
+}
+
+// experienceLevelIndex finds a job's experience level in
+// domain.JobExperienceLevels, ignoring case and punctuation.
+func experienceLevelIndex(level string) (int, bool) {
+	normalized := normalizeLevel(level)
+	if normalized == "" {
+		return 0, false
+	}
+
+	for i, known := range domain.JobExperienceLevels {
+		if normalizeLevel(known.Name) == normalized {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// viewerExperienceLevel is the most senior level the years of experience
+// qualify for.
+func viewerExperienceLevel(years float64) int {
+	level := 0
+	for i, known := range domain.JobExperienceLevels {
+		if years >= known.MinYears {
+			level = i
+		}
+	}
+	return level
+}
+
+// locationScore is how well a job fits the preference, between 0 and 1. It is
+// false when neither the preference nor the job has anything to compare.
+func locationScore(candidate JobCandidate, preference locationPreference) (float64, bool) {
+	var score float64
+	var signals int
+
+	if preference.LocationType != "" && candidate.LocationType != "" {
+		signals++
+		if strings.EqualFold(candidate.LocationType, preference.LocationType) {
+			score++
+		}
+	}
+
+	if strings.EqualFold(candidate.LocationType, domain.JobLocationTypeRemote) {
+		if preference.LocationType == "" {
+			signals++
+			score++
+		}
+	} else if preference.Location != "" && candidate.Location != "" {
+		signals++
+		wanted := strings.ToLower(preference.Location)
+		offered := strings.ToLower(candidate.Location)
+		if strings.Contains(wanted, offered) || strings.Contains(offered, wanted) {
+			score++
+		}
+	}
+
+	if signals == 0 {
+		return 0, false
+	}
+
+	return score / float64(signals), true
+}
+
+func scoreJob(candidate JobCandidate, viewerLevel int, preference locationPreference) jobMatch {
+	var match jobMatch
+
+	weights := float64(domain.JobMatchWeightSkills)
+	score := float64(domain.JobMatchWeightSkills)
+	if candidate.SkillCount > 0 {
+		score *= float64(candidate.MatchedCount) / float64(candidate.SkillCount)
+	}
+
+	if level, ok := experienceLevelIndex(candidate.ExperienceLevel); ok {
+		distance := math.Abs(float64(level - viewerLevel))
+		weights += domain.JobMatchWeightExperience
+		score += domain.JobMatchWeightExperience * math.Max(0, 1-distance/2)
+		match.ExperienceFits = distance == 0
+	}
+
+	if location, ok := locationScore(candidate, preference); ok {
+		weights += domain.JobMatchWeightLocation
+		score += domain.JobMatchWeightLocation * location
+		match.LocationFits = location == 1
+	}
+
+	match.Percentage = int(math.Round(score / weights * 100))
+
+	return match
+}
@@ -0,0 +1,231 @@
+package recommendation
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/job"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	RecommendationRepository interface {
+		GetPeopleYouMayKnow(ctx context.Context, userID uuid.UUID, limit int) ([]PeopleCandidate, error)
+		GetJobCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]JobCandidate, error)
+		GetJobsByIDs(ctx context.Context, jobIDs []uuid.UUID) ([]entities.Job, error)
+		GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error)
+		GetExperiences(ctx context.Context, userID uuid.UUID) ([]entities.UserExperience, error)
+	}
+
+	recommendationRepository struct {
+		db *gorm.DB
+	}
+
+	PeopleCandidate struct {
+		ID              uuid.UUID
+		Name            string
+		Slug            string
+		ProfilePicture  string
+		CurrentTitle    string
+		Score           int
+		MutualCount     int
+		SharedCompanies string
+		SharedSchools   string
+		SharedSkills    string
+	}
+
+	// JobCandidate is a listed job that asks for at least one of the viewer's
+	// skills. MatchedSkills and MissingSkills are JSON arrays of names.
+	JobCandidate struct {
+		ID              uuid.UUID
+		Location        string
+		LocationType    string
+		ExperienceLevel string
+		MatchedSkills   string
+		MissingSkills   string
+		MatchedCount    int
+		SkillCount      int
+	}
+)
+
+func NewRecommendationRepository(db *gorm.DB) RecommendationRepository {
+	return &recommendationRepository{db: db}
+}
+
+// peopleYouMayKnowSQL collects every user who shares a connection, an employer,
+// a school or a skill with @viewer and scores them with the weights passed in.
+// Users the viewer is already connected to, has a pending request with, or has
+// blocked or muted in either direction are left out. The shared_* columns are
+// JSON arrays of names.
+const peopleYouMayKnowSQL = `
+	WITH viewer_network AS (
+		SELECT CASE WHEN user_id = @viewer THEN connected_with_id ELSE user_id END AS member_id
+		FROM user_connections
+		WHERE (user_id = @viewer OR connected_with_id = @viewer) AND status = @accepted AND deleted_at IS NULL
+	),
+	excluded AS (
+		SELECT CASE WHEN user_id = @viewer THEN connected_with_id ELSE user_id END AS user_id
+		FROM user_connections
+		WHERE (user_id = @viewer OR connected_with_id = @viewer) AND status IN @active AND deleted_at IS NULL
+		UNION
+		SELECT CASE WHEN user_id = @viewer THEN target_user_id ELSE user_id END AS user_id
+		FROM user_blocks
+		WHERE user_id = @viewer OR target_user_id = @viewer
+	),
+	network_edges AS (
+		SELECT c.connected_with_id AS candidate_id, c.user_id AS via_id
+		FROM user_connections c
+		JOIN viewer_network v ON v.member_id = c.user_id
+		WHERE c.status = @accepted AND c.deleted_at IS NULL
+		UNION ALL
+		SELECT c.user_id AS candidate_id, c.connected_with_id AS via_id
+		FROM user_connections c
+		JOIN viewer_network v ON v.member_id = c.connected_with_id
+		WHERE c.status = @accepted AND c.deleted_at IS NULL
+	),
+	mutuals AS (
+		SELECT candidate_id, COUNT(DISTINCT via_id) AS mutual_count
+		FROM network_edges
+		GROUP BY candidate_id
+	),
+	employers AS (
+		SELECT other.user_id AS candidate_id, json_agg(DISTINCT co.name) AS shared_companies, COUNT(DISTINCT co.id) AS shared_count
+		FROM user_experiences mine
+		JOIN user_experiences other ON other.company_id = mine.company_id AND other.user_id <> mine.user_id AND other.deleted_at IS NULL
+		JOIN companies co ON co.id = mine.company_id
+		WHERE mine.user_id = @viewer AND mine.deleted_at IS NULL
+		GROUP BY other.user_id
+	),
+	schools AS (
+		SELECT other.user_id AS candidate_id, json_agg(DISTINCT mine.school_name) AS shared_schools, COUNT(DISTINCT LOWER(mine.school_name)) AS shared_count
+		FROM user_educations mine
+		JOIN user_educations other ON LOWER(other.school_name) = LOWER(mine.school_name) AND other.user_id <> mine.user_id AND other.deleted_at IS NULL
+		WHERE mine.user_id = @viewer AND mine.deleted_at IS NULL
+		GROUP BY other.user_id
+	),
+	skills AS (
+		SELECT other.user_id AS candidate_id, json_agg(DISTINCT sk.name) AS shared_skills, COUNT(DISTINCT sk.id) AS shared_count
+		FROM user_skills mine
+		JOIN user_skills other ON other.skill_id = mine.skill_id AND other.user_id <> mine.user_id AND other.deleted_at IS NULL
+		JOIN skills sk ON sk.id = mine.skill_id
+		WHERE mine.user_id = @viewer AND mine.deleted_at IS NULL
+		GROUP BY other.user_id
+	),
+	candidates AS (
+		SELECT candidate_id FROM mutuals
+		UNION SELECT candidate_id FROM employers
+		UNION SELECT candidate_id FROM schools
+		UNION SELECT candidate_id FROM skills
+	)
+	SELECT u.id, u.name, u.slug, u.profile_picture, u.current_title,
+		COALESCE(m.mutual_count, 0) AS mutual_count,
+		COALESCE(e.shared_companies, '[]')::text AS shared_companies,
+		COALESCE(sc.shared_schools, '[]')::text AS shared_schools,
+		COALESCE(sk.shared_skills, '[]')::text AS shared_skills,
+		COALESCE(m.mutual_count, 0) * @mutual_weight
+			+ COALESCE(e.shared_count, 0) * @employer_weight
+			+ COALESCE(sc.shared_count, 0) * @school_weight
+			+ COALESCE(sk.shared_count, 0) * @skill_weight AS score
+	FROM candidates c
+	JOIN users u ON u.id = c.candidate_id AND u.deleted_at IS NULL AND u.role = @role
+	LEFT JOIN mutuals m ON m.candidate_id = c.candidate_id
+	LEFT JOIN employers e ON e.candidate_id = c.candidate_id
+	LEFT JOIN schools sc ON sc.candidate_id = c.candidate_id
+	LEFT JOIN skills sk ON sk.candidate_id = c.candidate_id
+	WHERE c.candidate_id <> @viewer
+		AND NOT EXISTS (SELECT 1 FROM excluded x WHERE x.user_id = c.candidate_id)
+	ORDER BY score DESC, mutual_count DESC, u.id
+	LIMIT @limit`
+
+func (r *recommendationRepository) GetPeopleYouMayKnow(ctx context.Context, userID uuid.UUID, limit int) ([]PeopleCandidate, error) {
+	var candidates []PeopleCandidate
+
+	if err := r.db.WithContext(ctx).Raw(peopleYouMayKnowSQL, map[string]interface{}{
+		"viewer":          userID,
+		"accepted":        domain.ConnectionStatusAccepted,
+		"active":          []string{domain.ConnectionStatusAccepted, domain.ConnectionStatusPending},
+		"role":            domain.RoleUser,
+		"mutual_weight":   domain.RecommendationWeightMutual,
+		"employer_weight": domain.RecommendationWeightEmployer,
+		"school_weight":   domain.RecommendationWeightSchool,
+		"skill_weight":    domain.RecommendationWeightSkill,
+		"limit":           limit,
+	}).Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// GetJobCandidates lists the listed jobs sharing the largest part of their
+// skills with the user, leaving out the ones they already applied to.
+func (r *recommendationRepository) GetJobCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]JobCandidate, error) {
+	var candidates []JobCandidate
+
+	viewerSkills := r.db.Table("user_skills").Distinct("skill_id").Where("user_id = ? AND deleted_at IS NULL", userID)
+
+	matches := r.db.Table("job_skills").
+		Select(`job_skills.job_id,
+			COALESCE(json_agg(DISTINCT skills.name) FILTER (WHERE viewer_skills.skill_id IS NOT NULL), '[]')::text AS matched_skills,
+			COALESCE(json_agg(DISTINCT skills.name) FILTER (WHERE viewer_skills.skill_id IS NULL), '[]')::text AS missing_skills,
+			COUNT(DISTINCT skills.id) FILTER (WHERE viewer_skills.skill_id IS NOT NULL) AS matched_count,
+			COUNT(DISTINCT skills.id) AS skill_count`).
+		Joins("JOIN skills ON skills.id = job_skills.skill_id AND skills.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) AS viewer_skills ON viewer_skills.skill_id = job_skills.skill_id", viewerSkills).
+		Where("job_skills.deleted_at IS NULL").
+		Group("job_skills.job_id").
+		Having("COUNT(viewer_skills.skill_id) > 0")
+
+	err := r.db.WithContext(ctx).Model(&entities.Job{}).Scopes(job.Listed).
+		Select("jobs.id, jobs.location, jobs.location_type, jobs.experience_level, matches.matched_skills, matches.missing_skills, matches.matched_count, matches.skill_count").
+		Joins("JOIN (?) AS matches ON matches.job_id = jobs.id", matches).
+		Where("jobs.hidden_at IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM job_applications WHERE job_applications.job_id = jobs.id AND job_applications.user_id = ? AND job_applications.deleted_at IS NULL)", userID).
+		Order("matches.matched_count::double precision / matches.skill_count DESC").
+		Order("matches.matched_count DESC").
+		Order("jobs.created_at DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (r *recommendationRepository) GetJobsByIDs(ctx context.Context, jobIDs []uuid.UUID) ([]entities.Job, error) {
+	var jobs []entities.Job
+
+	if len(jobIDs) == 0 {
+		return jobs, nil
+	}
+
+	if err := r.db.WithContext(ctx).Preload("Company.User").Where("id IN ?", jobIDs).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *recommendationRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	var user entities.User
+
+	if err := r.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+		return entities.User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *recommendationRepository) GetExperiences(ctx context.Context, userID uuid.UUID) ([]entities.UserExperience, error) {
+	var experiences []entities.UserExperience
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&experiences).Error; err != nil {
+		return nil, err
+	}
+
+	return experiences, nil
+}
@@ -0,0 +1,266 @@
+package recommendation
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	RecommendationService interface {
+		GetPeopleYouMayKnow(ctx context.Context, userID string, limit int) ([]domain.PeopleRecommendationResponse, error)
+		GetJobsForYou(ctx context.Context, userID string, req domain.JobsForYouRequest) ([]domain.JobRecommendationResponse, error)
+	}
+
+	recommendationService struct {
+		recommendationRepository RecommendationRepository
+		jwtService               jwtService.JWTService
+	}
+)
+
+func NewRecommendationService(recommendationRepository RecommendationRepository, jwtService jwtService.JWTService) RecommendationService {
+	return &recommendationService{recommendationRepository: recommendationRepository, jwtService: jwtService}
+}
+
+func decodeNames(raw string) []string {
+	names := []string{}
+	if err := json.Unmarshal([]byte(raw), &names); err != nil {
+		return []string{}
+	}
+	return names
+}
+
+func plural(count int, singular string, pluralForm string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, pluralForm)
+}
+
+func buildReasons(mutualCount int, companies []string, schools []string, skills []string) []domain.RecommendationReasonResponse {
+	reasons := []domain.RecommendationReasonResponse{}
+
+	if mutualCount > 0 {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.RecommendationReasonMutual,
+			Message: plural(mutualCount, "mutual connection", "mutual connections"),
+		})
+	}
+
+	if len(companies) > 0 {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.RecommendationReasonEmployer,
+			Message: "Also worked at " + strings.Join(companies, ", "),
+		})
+	}
+
+	if len(schools) > 0 {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.RecommendationReasonSchool,
+			Message: "Also studied at " + strings.Join(schools, ", "),
+		})
+	}
+
+	if len(skills) > 0 {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.RecommendationReasonSkill,
+			Message: plural(len(skills), "shared skill", "shared skills") + ": " + strings.Join(skills, ", "),
+		})
+	}
+
+	return reasons
+}
+
+func (s *recommendationService) GetPeopleYouMayKnow(ctx context.Context, userID string, limit int) ([]domain.PeopleRecommendationResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	if limit <= 0 {
+		limit = domain.RecommendationDefaultLimit
+	}
+
+	if limit > domain.RecommendationMaxLimit {
+		limit = domain.RecommendationMaxLimit
+	}
+
+	candidates, err := s.recommendationRepository.GetPeopleYouMayKnow(ctx, parsedUserID, limit)
+
+	if err != nil {
+		return nil, domain.ErrGetRecommendations
+	}
+
+	recommendations := make([]domain.PeopleRecommendationResponse, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		companies := decodeNames(candidate.SharedCompanies)
+		schools := decodeNames(candidate.SharedSchools)
+		skills := decodeNames(candidate.SharedSkills)
+
+		recommendations = append(recommendations, domain.PeopleRecommendationResponse{
+			ID:              candidate.ID.String(),
+			Name:            candidate.Name,
+			Slug:            candidate.Slug,
+			ProfilePicture:  candidate.ProfilePicture,
+			Headline:        candidate.CurrentTitle,
+			Score:           candidate.Score,
+			MutualCount:     candidate.MutualCount,
+			SharedCompanies: companies,
+			SharedSchools:   schools,
+			SharedSkills:    skills,
+			Reasons:         buildReasons(candidate.MutualCount, companies, schools, skills),
+		})
+	}
+
+	return recommendations, nil
+}
+
+func buildJobReasons(candidate JobCandidate, match jobMatch) []domain.RecommendationReasonResponse {
+	reasons := []domain.RecommendationReasonResponse{
+		{
+			Type:    domain.JobMatchReasonSkills,
+			Message: fmt.Sprintf("You have %d of %s", candidate.MatchedCount, plural(candidate.SkillCount, "skill", "skills")),
+		},
+	}
+
+	if match.ExperienceFits {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.JobMatchReasonExperience,
+			Message: "Matches your experience level",
+		})
+	}
+
+	if match.LocationFits {
+		reasons = append(reasons, domain.RecommendationReasonResponse{
+			Type:    domain.JobMatchReasonLocation,
+			Message: "Matches your location preference",
+		})
+	}
+
+	return reasons
+}
+
+// GetJobsForYou scores the listed jobs asking for the user's skills and
+// returns the best matches with the skills they have and miss for each.
+func (s *recommendationService) GetJobsForYou(ctx context.Context, userID string, req domain.JobsForYouRequest) ([]domain.JobRecommendationResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	limit := req.Limit
+
+	if limit <= 0 {
+		limit = domain.RecommendationDefaultLimit
+	}
+
+	if limit > domain.RecommendationMaxLimit {
+		limit = domain.RecommendationMaxLimit
+	}
+
+	user, err := s.recommendationRepository.GetUserByID(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	experiences, err := s.recommendationRepository.GetExperiences(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetJobRecommendations
+	}
+
+	candidates, err := s.recommendationRepository.GetJobCandidates(ctx, parsedUserID, domain.JobRecommendationCandidatePool)
+
+	if err != nil {
+		return nil, domain.ErrGetJobRecommendations
+	}
+
+	preference := locationPreference{
+		Location:     strings.TrimSpace(req.Location),
+		LocationType: strings.TrimSpace(req.LocationType),
+	}
+
+	if preference.Location == "" {
+		preference.Location = strings.TrimSpace(user.Address)
+	}
+
+	viewerLevel := viewerExperienceLevel(experienceYears(experiences, time.Now()))
+
+	matches := make([]jobMatch, len(candidates))
+	order := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		matches[i] = scoreJob(candidate, viewerLevel, preference)
+		order[i] = i
+	}
+
+	// Candidates come ordered by skill overlap, which breaks ties.
+	sort.SliceStable(order, func(i, j int) bool {
+		return matches[order[i]].Percentage > matches[order[j]].Percentage
+	})
+
+	if len(order) > limit {
+		order = order[:limit]
+	}
+
+	jobIDs := make([]uuid.UUID, 0, len(order))
+	for _, i := range order {
+		jobIDs = append(jobIDs, candidates[i].ID)
+	}
+
+	jobs, err := s.recommendationRepository.GetJobsByIDs(ctx, jobIDs)
+
+	if err != nil {
+		return nil, domain.ErrGetJobRecommendations
+	}
+
+	jobsByID := make(map[uuid.UUID]int, len(jobs))
+	for i, job := range jobs {
+		jobsByID[job.ID] = i
+	}
+
+	recommendations := make([]domain.JobRecommendationResponse, 0, len(order))
+
+	for _, i := range order {
+		candidate := candidates[i]
+
+		index, ok := jobsByID[candidate.ID]
+		if !ok {
+			continue
+		}
+		job := jobs[index]
+
+		recommendations = append(recommendations, domain.JobRecommendationResponse{
+			ID:              job.ID.String(),
+			CompanyName:     job.Company.Name,
+			CompanyLogo:     job.Company.User.ProfilePicture,
+			CompanySlug:     job.Company.Slug,
+			Title:           job.Title,
+			Location:        job.Location,
+			LocationType:    job.LocationType,
+			JobType:         job.JobType,
+			ExperienceLevel: job.ExperienceLevel,
+			SalaryMin:       job.SalaryMin,
+			SalaryMax:       job.SalaryMax,
+			Deadline:        utils.ConvertOptionalTimestampToString(job.Deadline),
+			Posted:          utils.ConvertTimeToString(job.CreatedAt),
+			MatchPercentage: matches[i].Percentage,
+			MatchedSkills:   decodeNames(candidate.MatchedSkills),
+			MissingSkills:   decodeNames(candidate.MissingSkills),
+			Reasons:         buildJobReasons(candidate, matches[i]),
+		})
+	}
+
+	return recommendations, nil
+}
@@ -0,0 +1,75 @@
+package share
+
+import (
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/visibility"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	ShareRepository interface {
+		GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error)
+		CreateShare(ctx context.Context, post entities.Post) (entities.Post, error)
+		CountShares(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	}
+
+	shareRepository struct {
+		db *gorm.DB
+	}
+
+	shareCountRow struct {
+		ID    uuid.UUID
+		Count int64
+	}
+)
+
+func NewShareRepository(db *gorm.DB) ShareRepository {
+	return &shareRepository{db: db}
+}
+
+// GetPostByID finds a post the viewer is allowed to see.
+func (r *shareRepository) GetPostByID(ctx context.Context, postID uuid.UUID, viewerID uuid.UUID) (entities.Post, error) {
+	var post entities.Post
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Scopes(block.ExcludeBlocked(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		First(&post, "id = ?", postID).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *shareRepository) CreateShare(ctx context.Context, post entities.Post) (entities.Post, error) {
+	if err := r.db.WithContext(ctx).Create(&post).Error; err != nil {
+		return entities.Post{}, err
+	}
+	return post, nil
+}
+
+func (r *shareRepository) CountShares(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(postIDs))
+
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []shareCountRow
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Post{}).
+		Select("shared_post_id AS id, COUNT(*) AS count").
+		Where("shared_post_id IN ?", postIDs).
+		Group("shared_post_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ID] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,139 @@
+package share
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/attachment"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ShareService interface {
+		SharePost(ctx context.Context, req domain.SharePostRequest, userID string) error
+	}
+
+	shareService struct {
+		shareRepository        ShareRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewShareService(shareRepository ShareRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) ShareService {
+	return &shareService{
+		shareRepository:        shareRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+// ToSharedPostResponse builds the preview of the original embedded in a
+// share. It returns nil for regular posts and a placeholder when the
+// original was deleted or is hidden from the viewer.
+func ToSharedPostResponse(post entities.Post) *domain.SharedPostResponse {
+	if post.SharedPostID == nil {
+		return nil
+	}
+
+	if post.SharedPost == nil || post.SharedPost.User == nil {
+		return &domain.SharedPostResponse{
+			Content:       domain.SharedPostUnavailableContent,
+			IsUnavailable: true,
+		}
+	}
+
+	original := post.SharedPost
+
+	return &domain.SharedPostResponse{
+		ID:             original.ID.String(),
+		Name:           original.User.Name,
+		Headline:       original.User.CurrentTitle,
+		ProfilePicture: original.User.ProfilePicture,
+		Content:        original.Content,
+		Asset:          original.Asset,
+		CreatedAt:      utils.ConvertTimeToString(original.CreatedAt),
+		Slug:           original.User.Slug,
+		Type:           original.User.Role,
+		Attachments:    attachment.ToAttachmentResponses(original.Attachments),
+	}
+}
+
+// SharePost re-shares a post with optional commentary. Sharing a share
+// points the new post at the original instead of building a chain.
+func (s *shareService) SharePost(ctx context.Context, req domain.SharePostRequest, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	parsedPostID, err := uuid.Parse(req.PostID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	original, err := s.shareRepository.GetPostByID(ctx, parsedPostID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrPostNotFound
+	}
+
+	if original.SharedPostID != nil {
+		original, err = s.shareRepository.GetPostByID(ctx, *original.SharedPostID, parsedUserID)
+
+		if err != nil {
+			return domain.ErrPostNotFound
+		}
+	}
+
+	if original.Visibility != domain.PostVisibilityPublic {
+		return domain.ErrShareNonPublicPost
+	}
+
+	if req.Visibility == "" {
+		req.Visibility = domain.PostVisibilityPublic
+	}
+
+	share, err := s.shareRepository.CreateShare(ctx, entities.Post{
+		UserID:       parsedUserID,
+		Content:      req.Content,
+		SharedPostID: &original.ID,
+		Visibility:   req.Visibility,
+		Status:       domain.PostStatusPublished,
+	})
+
+	if err != nil {
+		return domain.ErrSharePost
+	}
+
+	if original.UserID == parsedUserID {
+		return nil
+	}
+
+	// The share is stored by now, so failing to tell the author does not fail
+	// the request: a retry would share the post twice.
+	share, err = s.shareRepository.GetPostByID(ctx, share.ID, parsedUserID)
+
+	if err != nil {
+		log.Printf("notify share of post %s: %v", original.ID, err)
+		return nil
+	}
+
+	notification.Notify(ctx, s.notificationRepository, entities.Notification{
+		UserID:           original.UserID,
+		Title:            "Post Shared",
+		Message:          share.User.Name + " shared your post",
+		IsRead:           false,
+		NotificationType: domain.NotificationTypeShare,
+		ReferenceID:      &original.ID,
+	})
+
+	return nil
+}
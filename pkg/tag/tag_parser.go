@@ -0,0 +1,132 @@
+package tag
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&#])#([\p{L}\p{N}_]+)`)
+	mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)`)
+	namePattern    = regexp.MustCompile(`^[\p{L}\p{N}_]+$`)
+	digitsPattern  = regexp.MustCompile(`^[0-9_]+$`)
+)
+
+// Entity is a hashtag or mention found in post content. Value is the
+// normalized hashtag name or slug, without the leading # or @.
+type Entity struct {
+	Type   string
+	Value  string
+	Offset int
+	Length int
+}
+
+// NormalizeHashtag lowercases a hashtag name and strips a leading #. It
+// returns false when the result is not a valid hashtag.
+func NormalizeHashtag(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimPrefix(name, "#"))
+
+	if !namePattern.MatchString(name) || digitsPattern.MatchString(name) || utf8.RuneCountInString(name) > domain.HashtagMaxLength {
+		return "", false
+	}
+
+	return name, true
+}
+
+// ParseContent finds the hashtags and @slug mentions in content, in order of
+// appearance.
+func ParseContent(content string) []Entity {
+	var found []Entity
+
+	for _, match := range hashtagPattern.FindAllStringSubmatchIndex(content, -1) {
+		name, ok := NormalizeHashtag(content[match[2]:match[3]])
+
+		if !ok {
+			continue
+		}
+
+		found = append(found, newEntity(content, domain.PostEntityHashtag, name, match[2]-1, match[3]))
+	}
+
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		slug := strings.ToLower(content[match[2]:match[3]])
+		found = append(found, newEntity(content, domain.PostEntityMention, slug, match[2]-1, match[3]))
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Offset < found[j].Offset
+	})
+
+	return found
+}
+
+// ExtractTags returns the distinct hashtag names and mentioned slugs in
+// content.
+func ExtractTags(content string) ([]string, []string) {
+	var hashtagNames, slugs []string
+	seen := map[Entity]bool{}
+
+	for _, entity := range ParseContent(content) {
+		key := Entity{Type: entity.Type, Value: entity.Value}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if entity.Type == domain.PostEntityHashtag {
+			hashtagNames = append(hashtagNames, entity.Value)
+		} else {
+			slugs = append(slugs, entity.Value)
+		}
+	}
+
+	return hashtagNames, slugs
+}
+
+func newEntity(content string, entityType string, value string, start int, end int) Entity {
+	return Entity{
+		Type:   entityType,
+		Value:  value,
+		Offset: utf8.RuneCountInString(content[:start]),
+		Length: utf8.RuneCountInString(content[start:end]),
+	}
+}
+
+// ToPostEntityResponses returns the entity ranges of a post. Mentions are
+// only linked when they resolved to an account, so post.Mentions must be
+// loaded.
+func ToPostEntityResponses(post entities.Post) []domain.PostEntityResponse {
+	mentionTypes := make(map[string]string, len(post.Mentions))
+	for _, mention := range post.Mentions {
+		mentionTypes[mention.Slug] = mention.Type
+	}
+
+	responses := make([]domain.PostEntityResponse, 0)
+
+	for _, entity := range ParseContent(post.Content) {
+		res := domain.PostEntityResponse{
+			Type:   entity.Type,
+			Offset: entity.Offset,
+			Length: entity.Length,
+			Value:  entity.Value,
+		}
+
+		if entity.Type == domain.PostEntityMention {
+			mentionType, ok := mentionTypes[entity.Value]
+
+			if !ok {
+				continue
+			}
+
+			res.MentionType = mentionType
+		}
+
+		responses = append(responses, res)
+	}
+
+	return responses
+}
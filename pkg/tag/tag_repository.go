@@ -0,0 +1,180 @@
+package tag
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/pkg/block"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	TagRepository interface {
+		GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error)
+		ResolveMentions(ctx context.Context, slugs []string, authorID uuid.UUID) ([]entities.PostMention, error)
+		GetPostMentions(ctx context.Context, postID uuid.UUID) ([]entities.PostMention, error)
+		GetOrCreateHashtag(ctx context.Context, name string) (entities.Hashtag, error)
+		GetHashtagByName(ctx context.Context, name string) (entities.Hashtag, error)
+		FollowHashtag(ctx context.Context, follower entities.HashtagFollower) error
+		UnfollowHashtag(ctx context.Context, hashtagID uuid.UUID, userID uuid.UUID) (bool, error)
+		GetFollowedHashtags(ctx context.Context, userID uuid.UUID) ([]entities.HashtagFollower, error)
+	}
+
+	tagRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
+// ResolveMentions maps slugs to user and company accounts. Accounts that
+// blocked the author, or that the author blocked, are not resolved.
+func (r *tagRepository) ResolveMentions(ctx context.Context, slugs []string, authorID uuid.UUID) ([]entities.PostMention, error) {
+	if len(slugs) == 0 {
+		return []entities.PostMention{}, nil
+	}
+
+	var users []entities.User
+	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(authorID, "users.id")).
+		Where("slug IN ? AND role = ?", slugs, domain.RoleUser).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	var companies []entities.Companies
+	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(authorID, "companies.user_id")).
+		Where("slug IN ?", slugs).
+		Find(&companies).Error; err != nil {
+		return nil, err
+	}
+
+	mentions := make([]entities.PostMention, 0, len(users)+len(companies))
+	for _, user := range users {
+		mentions = append(mentions, entities.PostMention{Slug: user.Slug, UserID: user.ID, Type: domain.MentionTypeUser})
+	}
+	for _, company := range companies {
+		mentions = append(mentions, entities.PostMention{Slug: company.Slug, UserID: company.UserID, Type: domain.MentionTypeCompany})
+	}
+
+	return mentions, nil
+}
+
+func (r *tagRepository) GetPostMentions(ctx context.Context, postID uuid.UUID) ([]entities.PostMention, error) {
+	var mentions []entities.PostMention
+	if err := r.db.WithContext(ctx).Where("post_id = ?", postID).Find(&mentions).Error; err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+// SavePostTags replaces the hashtags and mentions stored for a post. It is
+// run in the transaction that writes the post, so a post is never left
+// without its tags.
+func SavePostTags(tx *gorm.DB, postID uuid.UUID, hashtagNames []string, mentions []entities.PostMention) error {
+	if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostHashtag{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Unscoped().Where("post_id = ?", postID).Delete(&entities.PostMention{}).Error; err != nil {
+		return err
+	}
+
+	if len(hashtagNames) > 0 {
+		hashtags := make([]entities.Hashtag, 0, len(hashtagNames))
+		for _, name := range hashtagNames {
+			hashtags = append(hashtags, entities.Hashtag{Name: name})
+		}
+
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&hashtags).Error; err != nil {
+			return err
+		}
+
+		hashtags = nil
+		if err := tx.Where("name IN ?", hashtagNames).Find(&hashtags).Error; err != nil {
+			return err
+		}
+
+		postHashtags := make([]entities.PostHashtag, 0, len(hashtags))
+		for _, hashtag := range hashtags {
+			postHashtags = append(postHashtags, entities.PostHashtag{PostID: postID, HashtagID: hashtag.ID})
+		}
+
+		if err := tx.Create(&postHashtags).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(mentions) > 0 {
+		for i := range mentions {
+			mentions[i].PostID = postID
+		}
+
+		if err := tx.Create(&mentions).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *tagRepository) GetOrCreateHashtag(ctx context.Context, name string) (entities.Hashtag, error) {
+	hashtag := entities.Hashtag{Name: name}
+
+	if err := r.db.WithContext(ctx).
+		Where(entities.Hashtag{Name: name}).
+		FirstOrCreate(&hashtag).Error; err != nil {
+		return entities.Hashtag{}, err
+	}
+
+	return hashtag, nil
+}
+
+func (r *tagRepository) GetHashtagByName(ctx context.Context, name string) (entities.Hashtag, error) {
+	var hashtag entities.Hashtag
+	if err := r.db.WithContext(ctx).First(&hashtag, "name = ?", name).Error; err != nil {
+		return entities.Hashtag{}, err
+	}
+	return hashtag, nil
+}
+
+func (r *tagRepository) FollowHashtag(ctx context.Context, follower entities.HashtagFollower) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&follower).Error
+}
+
+func (r *tagRepository) UnfollowHashtag(ctx context.Context, hashtagID uuid.UUID, userID uuid.UUID) (bool, error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("hashtag_id = ? AND user_id = ?", hashtagID, userID).
+		Delete(&entities.HashtagFollower{})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *tagRepository) GetFollowedHashtags(ctx context.Context, userID uuid.UUID) ([]entities.HashtagFollower, error) {
+	var followers []entities.HashtagFollower
+	if err := r.db.WithContext(ctx).
+		Preload("Hashtag").
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&followers).Error; err != nil {
+		return nil, err
+	}
+	return followers, nil
+}
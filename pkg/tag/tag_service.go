@@ -0,0 +1,125 @@
+package tag
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"Go-Starter-Template/internal/utils"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"Go-Starter-Template/pkg/notification"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type (
+	TagService interface {
+		FollowHashtag(ctx context.Context, name string, userID string) error
+		UnfollowHashtag(ctx context.Context, name string, userID string) error
+		GetFollowedHashtags(ctx context.Context, userID string) ([]domain.HashtagResponse, error)
+	}
+
+	tagService struct {
+		tagRepository          TagRepository
+		notificationRepository notification.NotificationRepository
+		jwtService             jwtService.JWTService
+	}
+)
+
+func NewTagService(tagRepository TagRepository, notificationRepository notification.NotificationRepository, jwtService jwtService.JWTService) TagService {
+	return &tagService{
+		tagRepository:          tagRepository,
+		notificationRepository: notificationRepository,
+		jwtService:             jwtService,
+	}
+}
+
+func (s *tagService) FollowHashtag(ctx context.Context, name string, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	name, ok := NormalizeHashtag(name)
+
+	if !ok {
+		return domain.ErrInvalidHashtag
+	}
+
+	hashtag, err := s.tagRepository.GetOrCreateHashtag(ctx, name)
+
+	if err != nil {
+		return domain.ErrFollowHashtag
+	}
+
+	err = s.tagRepository.FollowHashtag(ctx, entities.HashtagFollower{
+		HashtagID: hashtag.ID,
+		UserID:    parsedUserID,
+	})
+
+	if err != nil {
+		return domain.ErrFollowHashtag
+	}
+
+	return nil
+}
+
+func (s *tagService) UnfollowHashtag(ctx context.Context, name string, userID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ErrParseUUID
+	}
+
+	name, ok := NormalizeHashtag(name)
+
+	if !ok {
+		return domain.ErrInvalidHashtag
+	}
+
+	hashtag, err := s.tagRepository.GetHashtagByName(ctx, name)
+
+	if err != nil {
+		return domain.ErrHashtagNotFollowed
+	}
+
+	deleted, err := s.tagRepository.UnfollowHashtag(ctx, hashtag.ID, parsedUserID)
+
+	if err != nil {
+		return domain.ErrUnfollowHashtag
+	}
+
+	if !deleted {
+		return domain.ErrHashtagNotFollowed
+	}
+
+	return nil
+}
+
+func (s *tagService) GetFollowedHashtags(ctx context.Context, userID string) ([]domain.HashtagResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	followers, err := s.tagRepository.GetFollowedHashtags(ctx, parsedUserID)
+
+	if err != nil {
+		return nil, domain.ErrGetFollowedTags
+	}
+
+	hashtags := make([]domain.HashtagResponse, 0, len(followers))
+	for _, follower := range followers {
+		if follower.Hashtag == nil {
+			continue
+		}
+
+		hashtags = append(hashtags, domain.HashtagResponse{
+			Name:          follower.Hashtag.Name,
+			FollowedSince: utils.ConvertTimeToString(follower.CreatedAt),
+		})
+	}
+
+	return hashtags, nil
+}
@@ -0,0 +1,139 @@
+package trending
+
+import (
+	"Go-Starter-Template/domain"
+	"Go-Starter-Template/entities"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	TrendingRepository interface {
+		ComputeTrending(ctx context.Context, window string, since time.Time, limit int) error
+		GetTrending(ctx context.Context, window string) ([]entities.TrendingTopic, error)
+	}
+
+	trendingRepository struct {
+		db *gorm.DB
+	}
+
+	trendingRow struct {
+		ID         uuid.UUID
+		PostCount  int64
+		Engagement int64
+	}
+)
+
+func NewTrendingRepository(db *gorm.DB) TrendingRepository {
+	return &trendingRepository{db: db}
+}
+
+// recentPostsSQL selects the public posts published since @since together
+// with their stored engagement, the one the feed ranking is built on.
+const recentPostsSQL = `WITH recent AS (
+	SELECT p.id, p.engagement
+	FROM posts p
+	WHERE p.created_at >= @since
+		AND p.status = @published
+		AND p.visibility = @public
+		AND p.hidden_at IS NULL
+		AND p.deleted_at IS NULL
+)`
+
+var trendingOrderSQL = fmt.Sprintf("ORDER BY COUNT(*) * %d + SUM(recent.engagement) DESC, id LIMIT @limit", domain.TrendingWeightPost)
+
+var trendingHashtagsSQL = recentPostsSQL + `
+SELECT ph.hashtag_id AS id, COUNT(*) AS post_count, SUM(recent.engagement) AS engagement
+FROM recent
+JOIN post_hashtags ph ON ph.post_id = recent.id AND ph.deleted_at IS NULL
+GROUP BY ph.hashtag_id
+` + trendingOrderSQL
+
+var trendingCompaniesSQL = recentPostsSQL + `
+SELECT pm.user_id AS id, COUNT(*) AS post_count, SUM(recent.engagement) AS engagement
+FROM recent
+JOIN post_mentions pm ON pm.post_id = recent.id AND pm.type = @company AND pm.deleted_at IS NULL
+GROUP BY pm.user_id
+` + trendingOrderSQL
+
+// ComputeTrending aggregates the posts of one window and replaces its
+// summary rows, so readers never see a half written ranking.
+func (r *trendingRepository) ComputeTrending(ctx context.Context, window string, since time.Time, limit int) error {
+	args := map[string]interface{}{
+		"since":     since,
+		"published": domain.PostStatusPublished,
+		"public":    domain.PostVisibilityPublic,
+		"company":   domain.MentionTypeCompany,
+		"limit":     limit,
+	}
+
+	var hashtags, companies []trendingRow
+
+	if err := r.db.WithContext(ctx).Raw(trendingHashtagsSQL, args).Scan(&hashtags).Error; err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Raw(trendingCompaniesSQL, args).Scan(&companies).Error; err != nil {
+		return err
+	}
+
+	computedAt := time.Now()
+	topics := make([]entities.TrendingTopic, 0, len(hashtags)+len(companies))
+
+	for i, row := range hashtags {
+		topic := newTrendingTopic(window, domain.TrendingKindHashtag, i+1, row, computedAt)
+		topic.HashtagID = &row.ID
+		topics = append(topics, topic)
+	}
+
+	for i, row := range companies {
+		topic := newTrendingTopic(window, domain.TrendingKindCompany, i+1, row, computedAt)
+		topic.UserID = &row.ID
+		topics = append(topics, topic)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("trend_window = ?", window).Delete(&entities.TrendingTopic{}).Error; err != nil {
+			return err
+		}
+
+		if len(topics) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&topics).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func newTrendingTopic(window string, kind string, rank int, row trendingRow, computedAt time.Time) entities.TrendingTopic {
+	return entities.TrendingTopic{
+		Window:     window,
+		Kind:       kind,
+		Rank:       rank,
+		PostCount:  row.PostCount,
+		Engagement: row.Engagement,
+		Score:      row.PostCount*domain.TrendingWeightPost + row.Engagement,
+		ComputedAt: computedAt,
+	}
+}
+
+func (r *trendingRepository) GetTrending(ctx context.Context, window string) ([]entities.TrendingTopic, error) {
+	var topics []entities.TrendingTopic
+	if err := r.db.WithContext(ctx).
+		Preload("Hashtag").
+		Preload("User").
+		Where("trend_window = ?", window).
+		Order("kind ASC").
+		Order("rank ASC").
+		Find(&topics).Error; err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
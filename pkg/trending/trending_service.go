@@ -0,0 +1,89 @@
+package trending
+
+import (
+	"Go-Starter-Template/domain"
+	jwtService "Go-Starter-Template/pkg/jwt"
+	"context"
+	"time"
+)
+
+type (
+	TrendingService interface {
+		GetTrending(ctx context.Context, window string) (domain.TrendingResponse, error)
+		ComputeTrending(ctx context.Context) error
+	}
+
+	trendingService struct {
+		trendingRepository TrendingRepository
+		jwtService         jwtService.JWTService
+	}
+)
+
+func NewTrendingService(trendingRepository TrendingRepository, jwtService jwtService.JWTService) TrendingService {
+	return &trendingService{
+		trendingRepository: trendingRepository,
+		jwtService:         jwtService,
+	}
+}
+
+func (s *trendingService) GetTrending(ctx context.Context, window string) (domain.TrendingResponse, error) {
+	if window == "" {
+		window = domain.TrendingWindowDay
+	}
+
+	if _, ok := domain.TrendingWindows[window]; !ok {
+		return domain.TrendingResponse{}, domain.ErrInvalidTrendingWindow
+	}
+
+	topics, err := s.trendingRepository.GetTrending(ctx, window)
+
+	if err != nil {
+		return domain.TrendingResponse{}, domain.ErrGetTrending
+	}
+
+	res := domain.TrendingResponse{
+		Window:   window,
+		Hashtags: make([]domain.TrendingHashtagResponse, 0, len(topics)),
+		Topics:   make([]domain.TrendingTopicResponse, 0, len(topics)),
+	}
+
+	for _, topic := range topics {
+		res.UpdatedAt = topic.ComputedAt.Format(time.RFC3339)
+
+		switch {
+		case topic.Kind == domain.TrendingKindHashtag && topic.Hashtag != nil:
+			res.Hashtags = append(res.Hashtags, domain.TrendingHashtagResponse{
+				Name:       topic.Hashtag.Name,
+				Rank:       topic.Rank,
+				PostCount:  topic.PostCount,
+				Engagement: topic.Engagement,
+			})
+		case topic.Kind == domain.TrendingKindCompany && topic.User != nil:
+			res.Topics = append(res.Topics, domain.TrendingTopicResponse{
+				Type:           topic.Kind,
+				Name:           topic.User.Name,
+				Slug:           topic.User.Slug,
+				ProfilePicture: topic.User.ProfilePicture,
+				Rank:           topic.Rank,
+				PostCount:      topic.PostCount,
+				Engagement:     topic.Engagement,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// ComputeTrending refreshes the summary of every window. It is run
+// periodically by the scheduler started in the app config.
+func (s *trendingService) ComputeTrending(ctx context.Context) error {
+	now := time.Now()
+
+	for window, length := range domain.TrendingWindows {
+		if err := s.trendingRepository.ComputeTrending(ctx, window, now.Add(-length), domain.TrendingLimit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
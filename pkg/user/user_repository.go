@@ -4,10 +4,17 @@ import (
 	"Go-Starter-Template/domain"
 	"Go-Starter-Template/entities"
 	"Go-Starter-Template/internal/utils"
+	"Go-Starter-Template/pkg/attachment"
+	"Go-Starter-Template/pkg/block"
+	"Go-Starter-Template/pkg/share"
+	"Go-Starter-Template/pkg/tag"
+	"Go-Starter-Template/pkg/visibility"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -16,8 +23,9 @@ type (
 		CheckUserByEmail(ctx context.Context, email string) bool
 		GetUserByEmail(ctx context.Context, email string) (entities.User, error)
 		CheckUserByID(ctx context.Context, id string) bool
+		GetUserByID(ctx context.Context, id uuid.UUID) (entities.User, error)
 		UpdateSubscriptionStatus(ctx context.Context, userID string) error
-		GetProfile(ctx context.Context, slug string) (domain.UserProfileResponse, error)
+		GetProfile(ctx context.Context, slug string, viewerID uuid.UUID) (domain.UserProfileResponse, error)
 		UpdateProfile(ctx context.Context, user entities.User, userID uuid.UUID) error
 		PostEducation(ctx context.Context, req entities.UserEducation) error
 		UpdateEducation(ctx context.Context, req entities.UserEducation) error
@@ -27,12 +35,43 @@ type (
 		DeleteExperience(ctx context.Context, id uuid.UUID) error
 		PostSkill(ctx context.Context, req entities.UserSkill) error
 		DeleteSkill(ctx context.Context, id uuid.UUID) error
-		SearchUser(ctx context.Context, query domain.UserSearchRequest) ([]entities.User, error)
+		SearchUser(ctx context.Context, query domain.UserSearchRequest, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]entities.User, error)
 		GetSkills(ctx context.Context) ([]entities.Skill, error)
+		GetConnectionRelations(ctx context.Context, viewerID uuid.UUID, targetIDs []uuid.UUID, sampleSize int) (map[uuid.UUID]ConnectionRelation, error)
+		RecordProfileView(ctx context.Context, view entities.Views) error
+		GetProfileViewCounts(ctx context.Context, userID uuid.UUID, since time.Time) ([]ProfileViewCount, error)
+		CountUniqueProfileViewers(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+		GetRecentProfileViewers(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]ProfileViewer, error)
 	}
 	userRepository struct {
 		db *gorm.DB
 	}
+
+	ConnectionRelation struct {
+		IsConnected bool
+		MutualCount int
+		Mutuals     []entities.User
+	}
+
+	ProfileViewCount struct {
+		ViewedOn time.Time
+		Count    int64
+	}
+
+	ProfileViewer struct {
+		Viewer       entities.User
+		LastViewedAt time.Time
+	}
+
+	mutualConnectionRow struct {
+		OwnerID        uuid.UUID
+		MutualCount    int
+		ID             uuid.UUID
+		Name           string
+		Slug           string
+		ProfilePicture string
+		CurrentTitle   string
+	}
 )
 
 func NewUserRepository(db *gorm.DB) UserRepository {
@@ -69,6 +108,14 @@ func (r *userRepository) CheckUserByID(ctx context.Context, id string) bool {
 	return true
 }
 
+func (r *userRepository) GetUserByID(ctx context.Context, id uuid.UUID) (entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+		return entities.User{}, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (entities.User, error) {
 	var user entities.User
 	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
@@ -87,7 +134,7 @@ func (r *userRepository) UpdateSubscriptionStatus(ctx context.Context, userID st
 	return nil
 }
 
-func (r *userRepository) GetProfile(ctx context.Context, slug string) (domain.UserProfileResponse, error) {
+func (r *userRepository) GetProfile(ctx context.Context, slug string, viewerID uuid.UUID) (domain.UserProfileResponse, error) {
 	var user entities.User
 	var education []entities.UserEducation
 	var posts []entities.Post
@@ -95,6 +142,7 @@ func (r *userRepository) GetProfile(ctx context.Context, slug string) (domain.Us
 	var skill []entities.UserSkill
 
 	if err := r.db.WithContext(ctx).
+		Scopes(block.ExcludeBlocked(viewerID, "users.id")).
 		Where("slug = ?", slug).
 		First(&user).Error; err != nil {
 		return domain.UserProfileResponse{}, err
@@ -104,7 +152,15 @@ func (r *userRepository) GetProfile(ctx context.Context, slug string) (domain.Us
 		return domain.UserProfileResponse{}, err
 	}
 
-	if err := r.db.WithContext(ctx).Order("created_at desc").Find(&posts, "user_id = ?", user.ID).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Mentions").
+		Preload("Attachments", attachment.InOrder).
+		Preload("SharedPost", block.ExcludeHidden(viewerID, "posts.user_id"), visibility.VisibleTo(viewerID, "posts")).
+		Preload("SharedPost.User").
+		Preload("SharedPost.Attachments", attachment.InOrder).
+		Scopes(visibility.VisibleTo(viewerID, "posts")).
+		Order("created_at desc").
+		Find(&posts, "user_id = ?", user.ID).Error; err != nil {
 		return domain.UserProfileResponse{}, err
 	}
 
@@ -164,6 +220,11 @@ func (r *userRepository) GetProfile(ctx context.Context, slug string) (domain.Us
 			Asset:          post.Asset,
 			Slug:           user.Slug,
 			Type:           user.Role,
+			Visibility:     post.Visibility,
+			EditedAt:       utils.ConvertOptionalTimeToString(post.EditedAt),
+			Attachments:    attachment.ToAttachmentResponses(post.Attachments),
+			Entities:       tag.ToPostEntityResponses(post),
+			SharedPost:     share.ToSharedPostResponse(post),
 		}
 	}
 
@@ -249,16 +310,26 @@ func (r *userRepository) DeleteSkill(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *userRepository) SearchUser(ctx context.Context, query domain.UserSearchRequest) ([]entities.User, error) {
+func (r *userRepository) SearchUser(ctx context.Context, query domain.UserSearchRequest, viewerID uuid.UUID, pagination domain.PaginationRequest) ([]entities.User, error) {
 	var users []entities.User
 
-	dbQuery := r.db.WithContext(ctx).Model(&entities.User{})
+	dbQuery := r.db.WithContext(ctx).Model(&entities.User{}).Scopes(block.ExcludeBlocked(viewerID, "users.id"))
 
 	if query.Keyword != "" {
 		dbQuery = dbQuery.Where("name ILIKE ?", "%"+query.Keyword+"%")
 	}
 
-	if err := dbQuery.Find(&users).Error; err != nil {
+	if pagination.Cursor != "" {
+		name, id, err := utils.DecodeKeyCursor(pagination.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		dbQuery = dbQuery.Where("(users.name, users.id) > (?, ?)", name, id)
+	}
+
+	if err := dbQuery.Order("users.name ASC").Order("users.id ASC").Limit(pagination.Limit + 1).Find(&users).Error; err != nil {
 		return nil, err
 	}
 
@@ -272,3 +343,170 @@ func (r *userRepository) GetSkills(ctx context.Context) ([]entities.Skill, error
 	}
 	return skills, nil
 }
+
+// viewerNetworkSQL selects the accepted first degree connections of @viewer.
+const viewerNetworkSQL = `
+	SELECT CASE WHEN user_id = @viewer THEN connected_with_id ELSE user_id END AS member_id
+	FROM user_connections
+	WHERE (user_id = @viewer OR connected_with_id = @viewer) AND status = @accepted AND deleted_at IS NULL`
+
+// GetConnectionRelations resolves, for every target, whether the viewer is
+// directly connected to it and which connections they share. Only the
+// neighbourhoods of the viewer and the targets are read, and at most
+// sampleSize mutual connections are returned per target.
+func (r *userRepository) GetConnectionRelations(ctx context.Context, viewerID uuid.UUID, targetIDs []uuid.UUID, sampleSize int) (map[uuid.UUID]ConnectionRelation, error) {
+	relations := make(map[uuid.UUID]ConnectionRelation, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return relations, nil
+	}
+
+	args := map[string]interface{}{
+		"viewer":   viewerID,
+		"targets":  targetIDs,
+		"accepted": domain.ConnectionStatusAccepted,
+		"sample":   sampleSize,
+	}
+
+	var connectedIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH viewer_network AS (`+viewerNetworkSQL+`)
+		SELECT member_id FROM viewer_network WHERE member_id IN @targets`, args).
+		Scan(&connectedIDs).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []mutualConnectionRow
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH viewer_network AS (`+viewerNetworkSQL+`),
+		target_network AS (
+			SELECT user_id AS owner_id, connected_with_id AS member_id
+			FROM user_connections
+			WHERE user_id IN @targets AND status = @accepted AND deleted_at IS NULL
+			UNION ALL
+			SELECT connected_with_id AS owner_id, user_id AS member_id
+			FROM user_connections
+			WHERE connected_with_id IN @targets AND status = @accepted AND deleted_at IS NULL
+		),
+		mutuals AS (
+			SELECT t.owner_id, t.member_id,
+				COUNT(*) OVER (PARTITION BY t.owner_id) AS mutual_count,
+				ROW_NUMBER() OVER (PARTITION BY t.owner_id ORDER BY t.member_id) AS position
+			FROM target_network t
+			JOIN viewer_network v ON v.member_id = t.member_id
+		)
+		SELECT m.owner_id, m.mutual_count, u.id, u.name, u.slug, u.profile_picture, u.current_title
+		FROM mutuals m
+		JOIN users u ON u.id = m.member_id AND u.deleted_at IS NULL
+		WHERE m.position <= @sample`, args).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range connectedIDs {
+		relation := relations[id]
+		relation.IsConnected = true
+		relations[id] = relation
+	}
+
+	for _, row := range rows {
+		relation := relations[row.OwnerID]
+		relation.MutualCount = row.MutualCount
+		relation.Mutuals = append(relation.Mutuals, entities.User{
+			ID:             row.ID,
+			Name:           row.Name,
+			Slug:           row.Slug,
+			ProfilePicture: row.ProfilePicture,
+			CurrentTitle:   row.CurrentTitle,
+		})
+		relations[row.OwnerID] = relation
+	}
+
+	return relations, nil
+}
+
+// RecordProfileView stores at most one view per viewer, profile and day.
+func (r *userRepository) RecordProfileView(ctx context.Context, view entities.Views) error {
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&view).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) GetProfileViewCounts(ctx context.Context, userID uuid.UUID, since time.Time) ([]ProfileViewCount, error) {
+	var counts []ProfileViewCount
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Views{}).
+		Select("viewed_on, COUNT(*) AS count").
+		Where("user_id = ? AND viewed_on >= ?", userID, since).
+		Group("viewed_on").
+		Order("viewed_on").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *userRepository) CountUniqueProfileViewers(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Views{}).
+		Where("user_id = ? AND viewed_on >= ?", userID, since).
+		Distinct("viewer_id").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetRecentProfileViewers lists who viewed the profile since the given time,
+// most recent first, leaving out users blocked either way.
+func (r *userRepository) GetRecentProfileViewers(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]ProfileViewer, error) {
+	var rows []struct {
+		ViewerID     uuid.UUID
+		LastViewedAt time.Time
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Views{}).
+		Select("viewer_id, MAX(created_at) AS last_viewed_at").
+		Scopes(block.ExcludeBlocked(userID, "views.viewer_id")).
+		Where("user_id = ? AND viewed_on >= ?", userID, since).
+		Group("viewer_id").
+		Order("last_viewed_at desc").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return []ProfileViewer{}, nil
+	}
+
+	viewerIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		viewerIDs = append(viewerIDs, row.ViewerID)
+	}
+
+	var users []entities.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", viewerIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	usersByID := make(map[uuid.UUID]entities.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	viewers := make([]ProfileViewer, 0, len(rows))
+	for _, row := range rows {
+		user, ok := usersByID[row.ViewerID]
+		if !ok {
+			continue
+		}
+		viewers = append(viewers, ProfileViewer{Viewer: user, LastViewedAt: row.LastViewedAt})
+	}
+
+	return viewers, nil
+}
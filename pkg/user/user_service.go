@@ -7,7 +7,9 @@ import (
 	"Go-Starter-Template/internal/utils/storage"
 	jwtService "Go-Starter-Template/pkg/jwt"
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,7 +19,7 @@ type (
 	UserService interface {
 		RegisterUser(ctx context.Context, req domain.UserRegisterRequest) (domain.UserRegisterResponse, error)
 		Login(ctx context.Context, req domain.UserLoginRequest) (domain.UserLoginResponse, error)
-		GetProfile(ctx context.Context, userID string) (domain.UserProfileResponse, error)
+		GetProfile(ctx context.Context, slug string, viewerID string) (domain.UserProfileResponse, error)
 		UpdateProfile(ctx context.Context, req domain.UpdateUserRequest, userID string) error
 		PostEducation(ctx context.Context, req domain.PostUserEducationRequest, userID string) error
 		DeleteEducation(ctx context.Context, educationID string) error
@@ -28,7 +30,8 @@ type (
 		PostSkill(ctx context.Context, req domain.PostUserSkillRequest, userID string) error
 		DeleteSkill(ctx context.Context, skillID string) error
 		GetSkills(ctx context.Context) ([]domain.SkillsResponse, error)
-		SearchUser(ctx context.Context, query domain.UserSearchRequest) ([]domain.UserSearchResponse, error)
+		SearchUser(ctx context.Context, query domain.UserSearchRequest, viewerID string, pagination domain.PaginationRequest) ([]domain.UserSearchResponse, domain.PaginationResponse, error)
+		GetProfileViews(ctx context.Context, userID string, days int) (domain.ProfileViewsResponse, error)
 	}
 
 	userService struct {
@@ -121,10 +124,14 @@ func (s *userService) Login(ctx context.Context, req domain.UserLoginRequest) (d
 		return domain.UserLoginResponse{}, domain.CredentialInvalid
 	}
 
-	if user.Role != "user" {
+	if user.Role != "user" && user.Role != domain.RoleAdmin {
 		return domain.UserLoginResponse{}, domain.ErrUserNotFound
 	}
 
+	if user.SuspendedUntil != nil && user.SuspendedUntil.After(time.Now()) {
+		return domain.UserLoginResponse{}, domain.ErrAccountSuspended
+	}
+
 	token := s.jwtService.GenerateTokenUser(user.ID.String(), user.Role)
 
 	return domain.UserLoginResponse{
@@ -138,17 +145,203 @@ func (s *userService) Login(ctx context.Context, req domain.UserLoginRequest) (d
 	}, nil
 }
 
-func (s *userService) GetProfile(ctx context.Context, slug string) (domain.UserProfileResponse, error) {
-	res, err := s.userRepository.GetProfile(ctx, slug)
+func (s *userService) GetProfile(ctx context.Context, slug string, viewerID string) (domain.UserProfileResponse, error) {
+	// An anonymous or malformed viewer parses to uuid.Nil, which skips block filtering.
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	res, err := s.userRepository.GetProfile(ctx, slug, parsedViewerID)
+
+	if err != nil {
+		return domain.UserProfileResponse{}, domain.ErrGetProfile
+	}
+
+	ownerID, err := uuid.Parse(res.PersonalInfo.ID)
+
+	if err != nil {
+		return domain.UserProfileResponse{}, domain.ErrParseUUID
+	}
+
+	relations, err := s.getConnectionRelations(ctx, viewerID, []uuid.UUID{ownerID})
 
 	if err != nil {
 		return domain.UserProfileResponse{}, domain.ErrGetProfile
 	}
 
+	if relation, ok := relations[ownerID]; ok {
+		res.Relationship = &relation
+	}
+
+	s.recordProfileView(ctx, ownerID, viewerID)
+
 	return res, nil
 
 }
 
+// recordProfileView counts an authenticated visit to someone else's profile.
+// A failure here must not break the profile page, so it is only logged.
+func (s *userService) recordProfileView(ctx context.Context, ownerID uuid.UUID, viewerID string) {
+	parsedViewerID, err := uuid.Parse(viewerID)
+
+	if err != nil || parsedViewerID == ownerID {
+		return
+	}
+
+	err = s.userRepository.RecordProfileView(ctx, entities.Views{
+		UserID:   ownerID,
+		ViewerID: parsedViewerID,
+		ViewedOn: utils.StartOfDay(time.Now()),
+	})
+
+	if err != nil {
+		log.Printf("failed to record profile view: %v", err)
+	}
+}
+
+func (s *userService) GetProfileViews(ctx context.Context, userID string, days int) (domain.ProfileViewsResponse, error) {
+	parsedUserID, err := uuid.Parse(userID)
+
+	if err != nil {
+		return domain.ProfileViewsResponse{}, domain.ErrParseUUID
+	}
+
+	if days <= 0 {
+		days = domain.ProfileViewsDefaultDays
+	}
+
+	if days > domain.ProfileViewsMaxDays {
+		days = domain.ProfileViewsMaxDays
+	}
+
+	user, err := s.userRepository.GetUserByID(ctx, parsedUserID)
+
+	if err != nil {
+		return domain.ProfileViewsResponse{}, domain.ErrUserNotFound
+	}
+
+	today := utils.StartOfDay(time.Now())
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.userRepository.GetProfileViewCounts(ctx, parsedUserID, since)
+
+	if err != nil {
+		return domain.ProfileViewsResponse{}, domain.ErrGetProfileViews
+	}
+
+	uniqueViewers, err := s.userRepository.CountUniqueProfileViewers(ctx, parsedUserID, since)
+
+	if err != nil {
+		return domain.ProfileViewsResponse{}, domain.ErrGetProfileViews
+	}
+
+	countsByDate := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		countsByDate[count.ViewedOn.Format("2006-01-02")] = count.Count
+	}
+
+	var totalViews int64
+	daily := make([]domain.ProfileViewCountResponse, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		count := countsByDate[day.Format("2006-01-02")]
+		totalViews += count
+		daily = append(daily, domain.ProfileViewCountResponse{
+			Date:  utils.ConvertTimeToString(day),
+			Count: count,
+		})
+	}
+
+	res := domain.ProfileViewsResponse{
+		TotalViews:    totalViews,
+		UniqueViewers: uniqueViewers,
+		Days:          days,
+		Daily:         daily,
+		ViewersLocked: !user.IsPremium,
+		RecentViewers: []domain.ProfileViewerResponse{},
+	}
+
+	if !user.IsPremium {
+		return res, nil
+	}
+
+	viewers, err := s.userRepository.GetRecentProfileViewers(ctx, parsedUserID, since, domain.ProfileViewersLimit)
+
+	if err != nil {
+		return domain.ProfileViewsResponse{}, domain.ErrGetProfileViews
+	}
+
+	for _, viewer := range viewers {
+		res.RecentViewers = append(res.RecentViewers, domain.ProfileViewerResponse{
+			ID:             viewer.Viewer.ID.String(),
+			Name:           viewer.Viewer.Name,
+			Slug:           viewer.Viewer.Slug,
+			ProfilePicture: viewer.Viewer.ProfilePicture,
+			Headline:       viewer.Viewer.CurrentTitle,
+			ViewedAt:       utils.ConvertTimeToString(viewer.LastViewedAt),
+		})
+	}
+
+	return res, nil
+}
+
+// getConnectionRelations describes how the viewer relates to each target. It
+// returns an empty map for anonymous viewers and never includes the viewer.
+func (s *userService) getConnectionRelations(ctx context.Context, viewerID string, targetIDs []uuid.UUID) (map[uuid.UUID]domain.ConnectionRelationResponse, error) {
+	relationsResponse := make(map[uuid.UUID]domain.ConnectionRelationResponse)
+
+	if viewerID == "" {
+		return relationsResponse, nil
+	}
+
+	parsedViewerID, err := uuid.Parse(viewerID)
+
+	if err != nil {
+		return nil, domain.ErrParseUUID
+	}
+
+	var others []uuid.UUID
+	for _, id := range targetIDs {
+		if id != parsedViewerID {
+			others = append(others, id)
+		}
+	}
+
+	relations, err := s.userRepository.GetConnectionRelations(ctx, parsedViewerID, others, domain.MutualConnectionSampleSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range others {
+		relation := relations[id]
+
+		degree := domain.ConnectionDegreeThird
+		if relation.IsConnected {
+			degree = domain.ConnectionDegreeFirst
+		} else if relation.MutualCount > 0 {
+			degree = domain.ConnectionDegreeSecond
+		}
+
+		mutuals := make([]domain.MutualConnectionResponse, 0, len(relation.Mutuals))
+		for _, mutual := range relation.Mutuals {
+			mutuals = append(mutuals, domain.MutualConnectionResponse{
+				ID:             mutual.ID.String(),
+				Name:           mutual.Name,
+				Slug:           mutual.Slug,
+				ProfilePicture: mutual.ProfilePicture,
+				Headline:       mutual.CurrentTitle,
+			})
+		}
+
+		relationsResponse[id] = domain.ConnectionRelationResponse{
+			Degree:            degree,
+			DegreeLabel:       domain.ConnectionDegreeLabels[degree],
+			MutualCount:       relation.MutualCount,
+			MutualConnections: mutuals,
+		}
+	}
+
+	return relationsResponse, nil
+}
+
 func (s *userService) UpdateProfile(ctx context.Context, req domain.UpdateUserRequest, userID string) error {
 	user := entities.User{
 		Name:         req.Name,
@@ -409,31 +602,63 @@ func (s *userService) DeleteSkill(ctx context.Context, skillID string) error {
 	return nil
 }
 
-func (s *userService) SearchUser(ctx context.Context, query domain.UserSearchRequest) ([]domain.UserSearchResponse, error) {
+func (s *userService) SearchUser(ctx context.Context, query domain.UserSearchRequest, viewerID string, pagination domain.PaginationRequest) ([]domain.UserSearchResponse, domain.PaginationResponse, error) {
 	var usersResponse []domain.UserSearchResponse
 
-	users, err := s.userRepository.SearchUser(ctx, query)
+	parsedViewerID, _ := uuid.Parse(viewerID)
+
+	users, err := s.userRepository.SearchUser(ctx, query, parsedViewerID, pagination)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, domain.PaginationResponse{}, err
+	}
+
+	if err != nil {
+		return nil, domain.PaginationResponse{}, domain.ErrSearchUser
+	}
+
+	users, hasMore := utils.TrimPage(users, pagination.Limit)
+
+	var meta domain.PaginationResponse
+
+	if hasMore {
+		last := users[len(users)-1]
+		meta = domain.PaginationResponse{NextCursor: utils.EncodeKeyCursor(last.Name, last.ID), HasMore: true}
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	relations, err := s.getConnectionRelations(ctx, viewerID, userIDs)
 
 	if err != nil {
-		return nil, domain.ErrSearchUser
+		return nil, domain.PaginationResponse{}, domain.ErrSearchUser
 	}
 
 	for _, user := range users {
-		usersResponse = append(usersResponse, domain.UserSearchResponse{
+		userResponse := domain.UserSearchResponse{
 			ID:             user.ID.String(),
 			Name:           user.Name,
 			Slug:           user.Slug,
 			Type:           user.Role,
 			ProfilePicture: user.ProfilePicture,
 			Headline:       user.CurrentTitle,
-		})
+		}
+
+		if relation, ok := relations[user.ID]; ok {
+			userResponse.Relationship = &relation
+		}
+
+		usersResponse = append(usersResponse, userResponse)
 	}
 
 	if usersResponse == nil {
 		usersResponse = []domain.UserSearchResponse{}
 	}
 
-	return usersResponse, nil
+	return usersResponse, meta, nil
 }
 
 func (s *userService) GetSkills(ctx context.Context) ([]domain.SkillsResponse, error) {
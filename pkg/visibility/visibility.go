@@ -0,0 +1,46 @@
+package visibility
+
+import (
+	"Go-Starter-Template/domain"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// visibleSQL lets the viewer see published posts that are not hidden by a
+// moderator and are public, their own, shared with their connections or, for
+// followers-only posts, written by the accounts of companies they follow.
+// Connections count as followers.
+const visibleSQL = `%[1]s.status = @published AND %[1]s.hidden_at IS NULL AND (%[1]s.visibility = @public
+	OR %[1]s.user_id = @viewer
+	OR (%[1]s.visibility IN (@connections, @followers) AND EXISTS (
+		SELECT 1 FROM user_connections uc
+		WHERE uc.status = @accepted AND uc.deleted_at IS NULL AND (
+			(uc.user_id = @viewer AND uc.connected_with_id = %[1]s.user_id) OR
+			(uc.user_id = %[1]s.user_id AND uc.connected_with_id = @viewer)
+		)))
+	OR (%[1]s.visibility = @followers AND EXISTS (
+		SELECT 1 FROM company_followers cf
+		JOIN companies co ON co.id = cf.company_id AND co.deleted_at IS NULL
+		WHERE co.user_id = %[1]s.user_id AND cf.user_id = @viewer AND cf.deleted_at IS NULL
+	)))`
+
+// VisibleTo keeps the posts in postTable that the viewer is allowed to see.
+// Drafts, scheduled posts and posts hidden by a moderator are never visible,
+// and anonymous viewers only see public posts.
+func VisibleTo(viewerID uuid.UUID, postTable string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db.Where(postTable+".status = ? AND "+postTable+".hidden_at IS NULL AND "+postTable+".visibility = ?", domain.PostStatusPublished, domain.PostVisibilityPublic)
+		}
+		return db.Where(fmt.Sprintf(visibleSQL, postTable), map[string]interface{}{
+			"viewer":      viewerID,
+			"published":   domain.PostStatusPublished,
+			"public":      domain.PostVisibilityPublic,
+			"connections": domain.PostVisibilityConnections,
+			"followers":   domain.PostVisibilityFollowers,
+			"accepted":    domain.ConnectionStatusAccepted,
+		})
+	}
+}